@@ -0,0 +1,8 @@
+variable "search_api_key" {
+  type      = string
+  sensitive = true
+}
+
+data "algolia_api_key" "example" {
+  key = var.search_api_key
+}
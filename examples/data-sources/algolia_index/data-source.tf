@@ -1,3 +1,17 @@
 data "algolia_index" "example" {
   name = "example"
 }
+
+# The settings blocks can be assigned to a new index as they are.
+resource "algolia_index" "copy" {
+  name = "example_copy"
+
+  dynamic "ranking_config" {
+    for_each = data.algolia_index.example.ranking_config
+    content {
+      ranking              = ranking_config.value.ranking
+      custom_ranking       = ranking_config.value.custom_ranking
+      relevancy_strictness = ranking_config.value.relevancy_strictness
+    }
+  }
+}
@@ -0,0 +1,8 @@
+data "algolia_query_suggestions" "example" {
+  index_name = "example_query_suggestions"
+  region     = "eu"
+}
+
+output "source_index_names" {
+  value = data.algolia_query_suggestions.example.source_indices[*].index_name
+}
@@ -0,0 +1,14 @@
+data "algolia_rule" "example" {
+  index_name = "example"
+  object_id  = "example-rule"
+}
+
+# The rule can be copied to another index.
+resource "algolia_rule" "copy" {
+  index_name = "example_copy"
+  object_id  = data.algolia_rule.example.object_id
+
+  consequence {
+    params_json = data.algolia_rule.example.consequence[0].params_json
+  }
+}
@@ -0,0 +1,11 @@
+resource "algolia_api_key" "search" {
+  acl = ["search"]
+}
+
+data "algolia_secured_api_key" "user_42" {
+  parent_api_key   = algolia_api_key.search.key
+  filters          = "visible_by:user_42"
+  restrict_indices = ["products"]
+  user_token       = "user_42"
+  valid_until      = "2030-01-01T00:00:00Z"
+}
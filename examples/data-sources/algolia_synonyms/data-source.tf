@@ -0,0 +1,22 @@
+data "algolia_synonyms" "example" {
+  index_name = "example"
+}
+
+# The synonyms can be copied to another index.
+resource "algolia_synonyms" "copy" {
+  index_name = "example_copy"
+
+  dynamic "synonyms" {
+    for_each = data.algolia_synonyms.example.synonyms
+    content {
+      object_id    = synonyms.value.object_id
+      type         = synonyms.value.type
+      synonyms     = synonyms.value.synonyms
+      input        = synonyms.value.input
+      word         = synonyms.value.word
+      corrections  = synonyms.value.corrections
+      placeholder  = synonyms.value.placeholder
+      replacements = synonyms.value.replacements
+    }
+  }
+}
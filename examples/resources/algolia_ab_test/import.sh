@@ -0,0 +1 @@
+terraform import algolia_ab_test.example {{region}}/{{ab_test_id}}
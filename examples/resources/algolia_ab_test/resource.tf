@@ -0,0 +1,24 @@
+resource "algolia_index" "example" {
+  name = "example"
+}
+
+resource "algolia_index" "example_variant" {
+  name = "example_variant"
+}
+
+resource "algolia_ab_test" "example" {
+  name = "example"
+
+  variants {
+    index_name         = algolia_index.example.name
+    traffic_percentage = 50
+    description        = "control"
+  }
+  variants {
+    index_name         = algolia_index.example_variant.name
+    traffic_percentage = 50
+    description        = "new ranking"
+  }
+
+  end_at = "2030-01-01T00:00:00Z"
+}
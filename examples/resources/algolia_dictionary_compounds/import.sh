@@ -0,0 +1 @@
+terraform import algolia_dictionary_compounds.example {{object_id}}
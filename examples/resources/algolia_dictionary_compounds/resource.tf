@@ -0,0 +1,5 @@
+resource "algolia_dictionary_compounds" "example" {
+  language      = "de"
+  word          = "kopfschmerztablette"
+  decomposition = ["kopf", "schmerz", "tablette"]
+}
@@ -0,0 +1 @@
+terraform import algolia_dictionary_plurals.example {{object_id}}
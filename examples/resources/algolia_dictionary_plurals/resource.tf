@@ -0,0 +1,4 @@
+resource "algolia_dictionary_plurals" "example" {
+  language = "en"
+  words    = ["mouse", "mice"]
+}
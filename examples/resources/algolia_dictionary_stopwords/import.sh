@@ -0,0 +1 @@
+terraform import algolia_dictionary_stopwords.example {{object_id}}
@@ -0,0 +1,5 @@
+resource "algolia_dictionary_stopwords" "example" {
+  language = "en"
+  word     = "the"
+  state    = "disabled"
+}
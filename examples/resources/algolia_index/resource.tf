@@ -33,6 +33,14 @@ resource "algolia_index" "primary" {
     sort_facet_values_by = "alpha"
   }
 
+  rendering_config {
+    facet_ordering {
+      facets {
+        order = ["category"]
+      }
+    }
+  }
+
   languages_config {
     remove_stop_words_for = ["en"]
   }
@@ -43,3 +51,13 @@ resource "algolia_index" "replica" {
   primary_index_name = algolia_index.primary.name
   // Configure settings for this replica
 }
+
+# The replicas can also be declared on the primary index, when they don't need their own settings.
+resource "algolia_index" "primary_with_replicas" {
+  name = "primary-index-with-replicas"
+
+  replicas = [
+    "primary-index-with-replicas_price_asc",
+    "virtual(primary-index-with-replicas_price_desc)",
+  ]
+}
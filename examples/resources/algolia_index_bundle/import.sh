@@ -0,0 +1 @@
+terraform import algolia_index_bundle.default {{index_name}}
@@ -0,0 +1,29 @@
+resource "algolia_index_bundle" "example" {
+  name = "example"
+
+  settings_json = jsonencode({
+    searchableAttributes  = ["title", "description"]
+    attributesForFaceting = ["brand"]
+  })
+
+  synonyms {
+    object_id = "phone"
+    type      = "synonym"
+    synonyms  = ["smartphone", "mobile phone", "cell phone"]
+  }
+
+  rules {
+    object_id = "brand"
+
+    conditions {
+      pattern   = "{facet:brand}"
+      anchoring = "contains"
+    }
+
+    consequence {
+      params_json = jsonencode({
+        automaticFacetFilters = [{ facet = "brand", disjunctive = true }]
+      })
+    }
+  }
+}
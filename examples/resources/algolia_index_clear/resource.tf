@@ -0,0 +1,9 @@
+resource "algolia_index_clear" "example" {
+  index_name      = "example_index"
+  allow_data_loss = true
+
+  # Change the value to clear the index again.
+  triggers = {
+    reset_at = "2021-01-01"
+  }
+}
@@ -0,0 +1 @@
+terraform import algolia_localized_indices.example {{base_name}}:{{locale}},{{locale}}
@@ -0,0 +1,23 @@
+# Creates `products_en`, `products_ja` and `products_fr` sharing the settings below.
+resource "algolia_localized_indices" "products" {
+  base_name = "products"
+  locales   = ["en", "ja", "fr"]
+
+  attributes_config {
+    searchable_attributes = [
+      "name",
+      "description",
+    ]
+    attributes_for_faceting = [
+      "category",
+    ]
+  }
+
+  ranking_config {
+    custom_ranking = ["desc(popularity)"]
+  }
+
+  languages_config {
+    remove_stop_words_for = ["en", "ja", "fr"]
+  }
+}
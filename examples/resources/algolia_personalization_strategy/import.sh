@@ -0,0 +1 @@
+terraform import algolia_personalization_strategy.example {{region}}/{{app_id}}
@@ -0,0 +1,19 @@
+resource "algolia_personalization_strategy" "example" {
+  event_scoring {
+    event_name = "Add to cart"
+    event_type = "conversion"
+    score      = 50
+  }
+  event_scoring {
+    event_name = "Product viewed"
+    event_type = "view"
+    score      = 10
+  }
+
+  facet_scoring {
+    facet_name = "brand"
+    score      = 100
+  }
+
+  personalization_impact = 50
+}
@@ -8,11 +8,9 @@ resource "algolia_rule" "example" {
   }
 
   consequence {
-    params_json = jsonencode({
-      automaticFacetFilters = {
-        facet       = "category"
-        disjunctive = true
-      }
-    })
+    automatic_facet_filters {
+      facet       = "category"
+      disjunctive = true
+    }
   }
 }
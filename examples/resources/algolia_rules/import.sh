@@ -0,0 +1 @@
+terraform import algolia_rules.default {{index_name}}
@@ -0,0 +1,35 @@
+resource "algolia_index" "example" {
+  name = "example"
+}
+
+resource "algolia_rules" "example" {
+  index_name = algolia_index.example.name
+
+  rules {
+    object_id = "brand"
+
+    conditions {
+      pattern   = "{facet:brand}"
+      anchoring = "contains"
+    }
+
+    consequence {
+      params_json = jsonencode({
+        automaticFacetFilters = [{ facet = "brand", disjunctive = true }]
+      })
+    }
+  }
+  rules {
+    object_id = "sale"
+
+    conditions {
+      context = "sale"
+    }
+
+    consequence {
+      params_json = jsonencode({
+        filters = "on_sale:true"
+      })
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import algolia_synonym.default {{index_name}}/{{object_id}}
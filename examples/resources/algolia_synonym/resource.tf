@@ -0,0 +1,19 @@
+resource "algolia_index" "example" {
+  name = "example"
+}
+
+resource "algolia_synonym" "smartphone" {
+  index_name = algolia_index.example.name
+  object_id  = "smartphone"
+  type       = "oneWaySynonym"
+  input      = "smartphone"
+  synonyms   = ["iPhone", "Pixel"]
+}
+
+resource "algolia_synonym" "tablet" {
+  index_name  = algolia_index.example.name
+  object_id   = "tablet"
+  type        = "altCorrection1"
+  word        = "tablet"
+  corrections = ["ipad"]
+}
@@ -0,0 +1,68 @@
+package algoliautil
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// DictionaryClient is the subset of *search.Client to manage dictionary entries.
+type DictionaryClient interface {
+	SaveDictionaryEntries(dictionaryName search.DictionaryName, dictionaryEntries []search.DictionaryEntry, opts ...interface{}) (search.UpdateTaskRes, error)
+	SearchDictionaryEntries(dictionaryName search.DictionaryName, query string, opts ...interface{}) (search.SearchDictionariesRes, error)
+	DeleteDictionaryEntries(dictionaryName search.DictionaryName, objectIDs []string, opts ...interface{}) (search.UpdateTaskRes, error)
+}
+
+// SaveDictionaryEntry adds or replaces the entry of the dictionary and waits for the task to finish.
+func SaveDictionaryEntry(ctx context.Context, client DictionaryClient, dictionaryName search.DictionaryName, entry search.DictionaryEntry) error {
+	res, err := client.SaveDictionaryEntries(dictionaryName, []search.DictionaryEntry{entry}, ctx)
+	if err != nil {
+		return err
+	}
+	return res.Wait()
+}
+
+// DeleteDictionaryEntry deletes the entry of the dictionary and waits for the task to finish.
+func DeleteDictionaryEntry(ctx context.Context, client DictionaryClient, dictionaryName search.DictionaryName, objectID string) error {
+	res, err := client.DeleteDictionaryEntries(dictionaryName, []string{objectID}, ctx)
+	if err != nil {
+		return err
+	}
+	return res.Wait()
+}
+
+// FindCustomDictionaryEntry searches the dictionary for the custom entry with the given objectID, and returns the raw entry
+// or nil if it's not found. Algolia's standard entries are never returned.
+// The search is narrowed down by the query and the language when they are given.
+func FindCustomDictionaryEntry(ctx context.Context, client DictionaryClient, dictionaryName search.DictionaryName, objectID string, query string, language string) (map[string]interface{}, error) {
+	opts := []interface{}{opt.HitsPerPage(1000), ctx}
+	if language != "" {
+		opts = append(opts, opt.Language(language))
+	}
+
+	for page := 0; ; page++ {
+		res, err := client.SearchDictionaryEntries(dictionaryName, query, append(opts, opt.Page(page))...)
+		if err != nil {
+			return nil, HumanizeError(err)
+		}
+		// Raw hits are decoded instead of DictionaryEntries since the client fails to decode entries of other shapes.
+		b, err := json.Marshal(res.Hits)
+		if err != nil {
+			return nil, err
+		}
+		var hits []map[string]interface{}
+		if err := json.Unmarshal(b, &hits); err != nil {
+			return nil, err
+		}
+		for _, hit := range hits {
+			if hit["objectID"] == objectID && hit["type"] != "standard" {
+				return hit, nil
+			}
+		}
+		if page+1 >= res.NbPages {
+			return nil, nil
+		}
+	}
+}
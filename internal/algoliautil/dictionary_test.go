@@ -0,0 +1,71 @@
+package algoliautil
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// fakeDictionaryClient is a DictionaryClient which serves the given pages of hits.
+type fakeDictionaryClient struct {
+	DictionaryClient
+	pages [][]interface{}
+}
+
+func (c *fakeDictionaryClient) SearchDictionaryEntries(dictionaryName search.DictionaryName, query string, opts ...interface{}) (search.SearchDictionariesRes, error) {
+	page := 0
+	for _, o := range opts {
+		if p, ok := o.(interface{ Get() int }); ok {
+			page = p.Get()
+		}
+	}
+	return search.SearchDictionariesRes{Hits: c.pages[page], Page: page, NbPages: len(c.pages)}, nil
+}
+
+func TestFindCustomDictionaryEntry(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeDictionaryClient{pages: [][]interface{}{
+		{
+			map[string]interface{}{"objectID": "standard", "language": "en", "word": "the", "type": "standard"},
+		},
+		{
+			map[string]interface{}{"objectID": "custom", "language": "en", "word": "foo", "type": "custom"},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		objectID string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "returns the custom entry in the later page",
+			objectID: "custom",
+			want:     map[string]interface{}{"objectID": "custom", "language": "en", "word": "foo", "type": "custom"},
+		},
+		{
+			name:     "returns nil for the standard entry",
+			objectID: "standard",
+			want:     nil,
+		},
+		{
+			name:     "returns nil if the entry doesn't exist",
+			objectID: "unknown",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindCustomDictionaryEntry(context.Background(), client, search.Stopwords, tt.objectID, "", "")
+			if err != nil {
+				t.Fatalf("FindCustomDictionaryEntry() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindCustomDictionaryEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package algoliautil
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
@@ -20,3 +22,27 @@ func IsNotFoundError(err error) bool {
 	_, ok := errs.IsAlgoliaErrWithCode(err, http.StatusNotFound)
 	return ok
 }
+
+// HumanizeError returns the error with a hint to fix it if it's a common error whose message from Algolia is hard to act on.
+// Otherwise, the error is returned as it is.
+func HumanizeError(err error) error {
+	switch status := algoliaErrStatus(err); status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: check that `app_id` and `api_key` are correct and the API key has the ACLs required for the operation", err)
+	default:
+		return err
+	}
+}
+
+// algoliaErrStatus returns the HTTP status of the Algolia API error wrapped in the error, or 0 if there is none.
+func algoliaErrStatus(err error) int {
+	var algoliaErr errs.AlgoliaErr
+	if errors.As(err, &algoliaErr) {
+		return algoliaErr.Status
+	}
+	var algoliaErrPtr *errs.AlgoliaErr
+	if errors.As(err, &algoliaErrPtr) {
+		return algoliaErrPtr.Status
+	}
+	return 0
+}
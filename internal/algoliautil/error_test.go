@@ -2,7 +2,9 @@ package algoliautil
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
@@ -96,3 +98,53 @@ func TestIsNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestHumanizeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantHint bool
+	}{
+		{
+			name:     "adds hint to unauthorized error",
+			err:      errs.AlgoliaErr{Message: "Invalid Application-ID or API key", Status: http.StatusUnauthorized},
+			wantHint: true,
+		},
+		{
+			name:     "adds hint to forbidden error",
+			err:      &errs.AlgoliaErr{Message: "Method not allowed with this API key", Status: http.StatusForbidden},
+			wantHint: true,
+		},
+		{
+			name:     "adds hint to wrapped forbidden error",
+			err:      fmt.Errorf("failed to get rule: %w", errs.AlgoliaErr{Message: "Method not allowed with this API key", Status: http.StatusForbidden}),
+			wantHint: true,
+		},
+		{
+			name:     "returns not found error as it is",
+			err:      errs.AlgoliaErr{Message: "not found", Status: http.StatusNotFound},
+			wantHint: false,
+		},
+		{
+			name:     "returns not algolia error as it is",
+			err:      errors.New("test"),
+			wantHint: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HumanizeError(tt.err)
+			if !errors.Is(got, tt.err) && got != tt.err {
+				t.Errorf("HumanizeError() = %v, want to wrap %v", got, tt.err)
+			}
+			if hasHint := strings.Contains(got.Error(), "check that `app_id` and `api_key` are correct"); hasHint != tt.wantHint {
+				t.Errorf("HumanizeError() = %v, wantHint %v", got, tt.wantHint)
+			}
+		})
+	}
+	if got := HumanizeError(nil); got != nil {
+		t.Errorf("HumanizeError(nil) = %v, want nil", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httputil"
+	"regexp"
 	"strings"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/transport"
@@ -38,9 +39,11 @@ type debugTransport struct {
 
 func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
+	// The request / response of API keys contain the key itself, which must not be logged.
+	maskAPIKeys := strings.HasPrefix(req.URL.Path, "/1/keys")
 	reqData, err := httputil.DumpRequestOut(req, true)
 	if err == nil {
-		tflog.Debug(ctx, fmt.Sprintf(logReqMsg, t.name, prettyPrintJsonLines(reqData)))
+		tflog.Debug(ctx, fmt.Sprintf(logReqMsg, t.name, prettyPrintJsonLines(reqData, maskAPIKeys)))
 	} else {
 		tflog.Error(ctx, fmt.Sprintf("%s API Request error: %#v", t.name, err))
 	}
@@ -52,7 +55,7 @@ func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	respData, err := httputil.DumpResponse(resp, true)
 	if err == nil {
-		tflog.Debug(ctx, fmt.Sprintf(logRespMsg, t.name, prettyPrintJsonLines(respData)))
+		tflog.Debug(ctx, fmt.Sprintf(logRespMsg, t.name, prettyPrintJsonLines(respData, maskAPIKeys)))
 	} else {
 		tflog.Error(ctx, fmt.Sprintf("%s API Response error: %#v", t.name, err))
 	}
@@ -66,10 +69,19 @@ func newDebugTransport(t http.RoundTripper) *debugTransport {
 
 // prettyPrintJsonLines iterates through a []byte line-by-line,
 // transforming any lines that are complete json into pretty-printed json.
-func prettyPrintJsonLines(b []byte) string {
+// When maskAPIKeys is true, API keys in the path and json are masked as well.
+func prettyPrintJsonLines(b []byte, maskAPIKeys bool) string {
 	parts := strings.Split(string(b), "\n")
 	for i, p := range parts {
+		if maskAPIKeys {
+			p = apiKeyPathRegexp.ReplaceAllString(p, "${1}"+maskedValue)
+			parts[i] = p
+		}
 		if b := []byte(p); json.Valid(b) {
+			if maskAPIKeys {
+				b = maskAPIKeysInJSON(b)
+				parts[i] = string(b)
+			}
 			var out bytes.Buffer
 			if err := json.Indent(&out, b, "", " "); err != nil {
 				continue
@@ -91,6 +103,50 @@ func prettyPrintJsonLines(b []byte) string {
 	return strings.Join(parts, "\n")
 }
 
+const maskedValue = "********"
+
+// apiKeyPathRegexp matches the path to the specific API key, e.g. `/1/keys/{key}`.
+var apiKeyPathRegexp = regexp.MustCompile(`(/1/keys/)[^/?\s]+`)
+
+// apiKeyJSONFields are the fields which hold the API key in the request / response of API keys.
+var apiKeyJSONFields = map[string]bool{"key": true, "value": true}
+
+// maskAPIKeysInJSON masks the API keys in the given json recursively.
+func maskAPIKeysInJSON(b []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return b
+	}
+	masked, err := json.Marshal(maskAPIKeysInValue(v))
+	if err != nil {
+		return b
+	}
+	return masked
+}
+
+func maskAPIKeysInValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fieldValue := range v {
+			if _, ok := fieldValue.(string); ok && apiKeyJSONFields[k] {
+				v[k] = maskedValue
+				continue
+			}
+			v[k] = maskAPIKeysInValue(fieldValue)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = maskAPIKeysInValue(elem)
+		}
+	}
+	return v
+}
+
+// MaskAPIKey returns the masked API key to be logged safely.
+func MaskAPIKey(key string) string {
+	return strings.Repeat("*", len(key))
+}
+
 const logReqMsg = `%s API Request Details:
 ---[ REQUEST ]---------------------------------------
 %s
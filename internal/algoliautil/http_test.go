@@ -2,6 +2,7 @@ package algoliautil
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +15,52 @@ func TestNewDebugRequester(t *testing.T) {
 		t.Errorf("NewDebugRequester() = %v, want %v", got, nil)
 	}
 }
+
+func Test_prettyPrintJsonLines(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		b           []byte
+		maskAPIKeys bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "mask headers",
+			args: args{b: []byte("GET /1/indexes/test/settings HTTP/1.1\nX-Algolia-Api-Key: secret")},
+			want: "GET /1/indexes/test/settings HTTP/1.1\nX-Algolia-Api-Key: ******",
+		},
+		{
+			name: "don't mask json fields unless maskAPIKeys is true",
+			args: args{b: []byte(`{"key":"abc"}`)},
+			want: "{\n \"key\": \"abc\"\n}",
+		},
+		{
+			name: "mask API key in path",
+			args: args{b: []byte("GET /1/keys/secret HTTP/1.1"), maskAPIKeys: true},
+			want: "GET /1/keys/******** HTTP/1.1",
+		},
+		{
+			name: "mask API keys in json",
+			args: args{b: []byte(`{"keys":[{"value":"secret","acl":["search"]}],"key":"secret"}`), maskAPIKeys: true},
+			want: "{\n \"key\": \"********\",\n \"keys\": [\n  {\n   \"acl\": [\n    \"search\"\n   ],\n   \"value\": \"********\"\n  }\n ]\n}",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := prettyPrintJsonLines(tt.args.b, tt.args.maskAPIKeys)
+			if got != tt.want {
+				t.Errorf("prettyPrintJsonLines() = %q, want %q", got, tt.want)
+			}
+			if tt.args.maskAPIKeys && strings.Contains(got, "secret") {
+				t.Errorf("prettyPrintJsonLines() leaks API key: %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func dataSourceAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for an API key. It can be used to reference a key created outside Terraform.",
+		ReadContext: dataSourceAPIKeyRead,
+		// https://www.algolia.com/doc/api-reference/api-methods/get-api-key/
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The API key to look up.",
+			},
+			"acl": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "Set of permissions associated with the key.",
+			},
+			"max_hits_per_query": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of hits this API key can retrieve in one call.",
+			},
+			"max_queries_per_ip_per_hour": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of API calls allowed from an IP address per hour.",
+			},
+			"indexes": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "List of targeted indices.",
+			},
+			"referers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "List of referrers that can perform an operation.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the API key.",
+			},
+			"query_parameters": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL-encoded query parameters which are forced on the searches performed with the key.",
+			},
+			"restrict_sources": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IPv4 network allowed to use the key, in CIDR notation.",
+			},
+			"created_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The unix time at which the key has been created.",
+			},
+			"validity": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The remaining validity of the key in seconds at the time of reading. `0` means the key never expires.",
+			},
+		},
+	}
+}
+
+func dataSourceAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	keyID := d.Get("key").(string)
+	key, err := apiClient.searchClient.GetAPIKey(keyID, ctx)
+	if err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	d.SetId(strconv.FormatInt(key.CreatedAt.Unix(), 10))
+
+	values := mapToAPIKeyValues(keyID, key)
+	values["validity"] = int(key.Validity.Seconds())
+	if err := setValues(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceAPIKey_read(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{
+		"external-key": {
+			Value:                  "external-key",
+			ACL:                    []string{"search", "browse"},
+			Validity:               3600 * time.Second,
+			MaxHitsPerQuery:        100,
+			MaxQueriesPerIPPerHour: 1000,
+			Indexes:                []string{"dev_*"},
+			Referers:               []string{"https://algolia.com/*"},
+			Description:            "created outside Terraform",
+			CreatedAt:              createdAt,
+		},
+	}}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAPIKey().Schema, map[string]interface{}{"key": "external-key"})
+	if diags := dataSourceAPIKeyRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceAPIKeyRead() error = %v", diags)
+	}
+
+	if got, want := d.Id(), strconv.FormatInt(createdAt.Unix(), 10); got != want {
+		t.Errorf("id = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("acl")), []string{"browse", "search"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("acl = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("indexes")), []string{"dev_*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indexes = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("referers")), []string{"https://algolia.com/*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("referers = %v, want %v", got, want)
+	}
+	if got, want := d.Get("description").(string), "created outside Terraform"; got != want {
+		t.Errorf("description = %v, want %v", got, want)
+	}
+	if got, want := d.Get("max_hits_per_query").(int), 100; got != want {
+		t.Errorf("max_hits_per_query = %v, want %v", got, want)
+	}
+	if got, want := d.Get("max_queries_per_ip_per_hour").(int), 1000; got != want {
+		t.Errorf("max_queries_per_ip_per_hour = %v, want %v", got, want)
+	}
+	if got, want := d.Get("created_at").(int), int(createdAt.Unix()); got != want {
+		t.Errorf("created_at = %v, want %v", got, want)
+	}
+	if got, want := d.Get("validity").(int), 3600; got != want {
+		t.Errorf("validity = %v, want %v", got, want)
+	}
+}
+
+func TestDataSourceAPIKey_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{}}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAPIKey().Schema, map[string]interface{}{"key": "unknown-key"})
+	if diags := dataSourceAPIKeyRead(context.Background(), d, apiClient); !diags.HasError() {
+		t.Errorf("dataSourceAPIKeyRead() error = nil, want not found error")
+	}
+}
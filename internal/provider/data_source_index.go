@@ -9,7 +9,7 @@ import (
 
 func dataSourceIndex() *schema.Resource {
 	return &schema.Resource{
-		Description: "Data source for an index.",
+		Description: "Data source for an index. The index is read from the application configured in the provider (`app_id`), so use a provider alias to read an index of another application.",
 		ReadContext: dataSourceIndexRead,
 		// https://www.algolia.com/doc/api-reference/settings-api-parameters/
 		Schema: map[string]*schema.Schema{
@@ -62,6 +62,12 @@ func dataSourceIndex() *schema.Resource {
 							Computed:    true,
 							Description: "List of attributes to be retrieved at query time.",
 						},
+						"attributes_to_retrieve_ordered": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+							Description: "List of attributes to be retrieved at query time in the order configured in the index. Use it instead of `attributes_to_retrieve` when the order matters.",
+						},
 					},
 				},
 			},
@@ -88,6 +94,15 @@ func dataSourceIndex() *schema.Resource {
 							Computed:    true,
 							Description: "Relevancy threshold below which less relevant results aren’t included in the results",
 						},
+						"re_ranking_apply_filter": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeList,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+							Description: "Filters to restrict Dynamic Re-Ranking to the matching records. The filters in a nested list are combined with OR, and the nested lists are combined with AND.",
+						},
 						"replicas": {
 							Type:        schema.TypeSet,
 							Elem:        &schema.Schema{Type: schema.TypeString},
@@ -117,6 +132,70 @@ func dataSourceIndex() *schema.Resource {
 					},
 				},
 			},
+			"rendering_config": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The configuration for how the search results are rendered in the UI.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"facet_ordering": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The ordering of facets and their values.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"facets": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Description: "The ordering of facets.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"order": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Computed:    true,
+													Description: "List of facets in the order they should be displayed. Facets not listed are displayed after them.",
+												},
+											},
+										},
+									},
+									"values": {
+										Type:        schema.TypeSet,
+										Computed:    true,
+										Description: "The ordering of facet values, per facet.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"facet": {
+													Type:        schema.TypeString,
+													Computed:    true,
+													Description: "Name of the facet.",
+												},
+												"order": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Computed:    true,
+													Description: "List of facet values in the order they should be displayed.",
+												},
+												"sort_remaining_by": {
+													Type:        schema.TypeString,
+													Computed:    true,
+													Description: "How the facet values not listed in `order` are sorted.",
+												},
+												"hide": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Computed:    true,
+													Description: "List of facet values to hide.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"highlight_and_snippet_config": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -299,7 +378,27 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Type:        schema.TypeMap,
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Computed:    true,
-							Description: "Custom normalization which overrides the engine’s default normalization",
+							Description: "Custom normalization of the `default` language bucket which overrides the engine’s default normalization. It's empty when there are other language buckets, see `custom_normalization_by_language` then.",
+						},
+						"custom_normalization_by_language": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "Custom normalization per language bucket. It's only set when there are language buckets other than `default`.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"language": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Language bucket of the normalization.",
+									},
+									"normalization": {
+										Type:        schema.TypeMap,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Computed:    true,
+										Description: "Map of the characters to their normalized form.",
+									},
+								},
+							},
 						},
 						"query_languages": {
 							Type:        schema.TypeSet,
@@ -333,6 +432,11 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 				Computed:    true,
 				Description: "Whether to enable the Personalization feature.",
 			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Search mode the index uses to query for results.",
+			},
 			"query_strategy_config": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -479,7 +583,18 @@ This parameter is mainly intended to **limit the response size.** For example, i
 
 func dataSourceIndexRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId(d.Get("name").(string))
-	if err := refreshIndexState(ctx, d, m); err != nil {
+	settings, err := getIndexSettings(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if settings == nil {
+		return nil
+	}
+
+	values := mapToIndexResourceValues(d, *settings)
+	// `attributes_to_retrieve` is a set, so expose the ordered list as well.
+	values["attributes_config"].([]interface{})[0].(map[string]interface{})["attributes_to_retrieve_ordered"] = settings.AttributesToRetrieve.Get()
+	if err := setValues(d, values); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccDataSourceIndex(t *testing.T) {
@@ -27,12 +34,125 @@ func TestAccDataSourceIndex(t *testing.T) {
 					testCheckResourceListAttr(dataSourceName, "ranking_config.0.ranking", []string{"words", "proximity"}),
 					resource.TestCheckResourceAttr(dataSourceName, "faceting_config.0.max_values_per_facet", "50"),
 					resource.TestCheckResourceAttr(dataSourceName, "faceting_config.0.sort_facet_values_by", "alpha"),
+					resource.TestCheckResourceAttr(dataSourceName, "advanced_config.0.distinct", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "advanced_config.0.attribute_for_distinct", "url"),
 				),
 			},
 		},
 	})
 }
 
+func TestDataSourceIndex_read(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"attributesToRetrieve":["title","body"],"hitsPerPage":30}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceIndex().Schema, map[string]interface{}{"name": "test"})
+	if diags := dataSourceIndexRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceIndexRead() error = %v", diags)
+	}
+	if got := d.Get("pagination_config.0.hits_per_page").(int); got != 30 {
+		t.Errorf("pagination_config.0.hits_per_page = %v, want %v", got, 30)
+	}
+	if got, want := castStringList(d.Get("attributes_config.0.attributes_to_retrieve_ordered")), []string{"title", "body"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("attributes_config.0.attributes_to_retrieve_ordered = %v, want %v", got, want)
+	}
+}
+
+func TestDataSourceIndex_readFromConfiguredApp(t *testing.T) {
+	t.Parallel()
+
+	const appID = "configured_app"
+	requester := &testRequester{handler: func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Algolia-Application-Id"); got != appID {
+			t.Errorf("X-Algolia-Application-Id = %v, want %v", got, appID)
+		}
+		if !strings.HasPrefix(r.URL.Host, appID) {
+			t.Errorf("host = %v, want the host of %v", r.URL.Host, appID)
+		}
+		_, _ = w.Write([]byte(`{"hitsPerPage":30}`))
+	}}
+	apiClient := &apiClient{
+		appID:     appID,
+		apiKey:    "test",
+		requester: requester,
+		searchClient: algoliaSearchClient{search.NewClientWithConfig(search.Configuration{
+			AppID:     appID,
+			APIKey:    "test",
+			Requester: requester,
+		})},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceIndex().Schema, map[string]interface{}{"name": "test"})
+	if diags := dataSourceIndexRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceIndexRead() error = %v", diags)
+	}
+}
+
+func TestDataSourceIndex_readDistinct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		settingsJSON string
+		wantDistinct int
+	}{
+		{
+			name:         "grouping",
+			settingsJSON: `{"attributeForDistinct":"url","distinct":3}`,
+			wantDistinct: 3,
+		},
+		{
+			name:         "de-duplication as boolean",
+			settingsJSON: `{"attributeForDistinct":"url","distinct":true}`,
+			wantDistinct: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+					_, _ = w.Write([]byte(tt.settingsJSON))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			dataSourceData := schema.TestResourceDataRaw(t, dataSourceIndex().Schema, map[string]interface{}{"name": "test"})
+			if diags := dataSourceIndexRead(context.Background(), dataSourceData, apiClient); diags.HasError() {
+				t.Fatalf("dataSourceIndexRead() error = %v", diags)
+			}
+			resourceData := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{"name": "test"})
+			resourceData.SetId("test")
+			if diags := resourceIndexRead(context.Background(), resourceData, apiClient); diags.HasError() {
+				t.Fatalf("resourceIndexRead() error = %v", diags)
+			}
+
+			for _, d := range []*schema.ResourceData{dataSourceData, resourceData} {
+				if got := d.Get("advanced_config.0.distinct").(int); got != tt.wantDistinct {
+					t.Errorf("advanced_config.0.distinct = %v, want %v", got, tt.wantDistinct)
+				}
+				if got := d.Get("advanced_config.0.attribute_for_distinct").(string); got != "url" {
+					t.Errorf("advanced_config.0.attribute_for_distinct = %v, want %v", got, "url")
+				}
+			}
+		})
+	}
+}
+
 func testAccDatasourceIndex(name string) string {
 	return `
 resource "algolia_index" "` + name + `" {
@@ -71,6 +191,10 @@ resource "algolia_index" "` + name + `" {
   languages_config {
     remove_stop_words_for = ["en"]
   }
+  advanced_config {
+    attribute_for_distinct = "url"
+    distinct               = 2
+  }
   deletion_protection = false
 }
 
@@ -82,3 +206,88 @@ data "algolia_index" "` + name + `" {
 }
 `
 }
+
+func TestDataSourceIndex_assignableToResource(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{
+				"searchableAttributes":["title","unordered(description)"],
+				"attributesForFaceting":["category"],
+				"attributesToRetrieve":["*"],
+				"ranking":["typo","geo","words","filters","proximity","attribute","exact","custom"],
+				"customRanking":["desc(popularity)"],
+				"replicas":["test_replica"],
+				"maxValuesPerFacet":100,
+				"sortFacetValuesBy":"count",
+				"hitsPerPage":20,
+				"paginationLimitedTo":1000,
+				"minWordSizefor1Typo":4,
+				"minWordSizefor2Typos":8,
+				"typoTolerance":true,
+				"ignorePlurals":["en"],
+				"removeStopWords":false,
+				"queryLanguages":["en"],
+				"queryType":"prefixLast",
+				"removeWordsIfNoResults":"none",
+				"exactOnSingleWordQuery":"attribute"
+			}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceIndex().Schema, map[string]interface{}{"name": "test"})
+	if diags := dataSourceIndexRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceIndexRead() error = %v", diags)
+	}
+
+	// Assign every block of the data source to a new index as it is.
+	resourceSchema := resourceIndex().Schema
+	raw := map[string]interface{}{"name": "new_index"}
+	for key, s := range dataSourceIndex().Schema {
+		if _, ok := s.Elem.(*schema.Resource); !ok {
+			continue
+		}
+		raw[key] = toRawConfigValue(d.Get(key), resourceSchema[key])
+	}
+	config := terraform.NewResourceConfigRaw(raw)
+	if diags := resourceIndex().Validate(config); diags.HasError() {
+		t.Errorf("Validate() error = %v", diags)
+	}
+	if _, err := resourceIndex().Diff(context.Background(), nil, config, apiClient); err != nil {
+		t.Errorf("Diff() error = %v", err)
+	}
+}
+
+// toRawConfigValue converts the value read from ResourceData to the raw config of the given schema,
+// dropping the attributes which the schema doesn't have.
+func toRawConfigValue(v interface{}, s *schema.Schema) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return toRawConfigValue(v.List(), s)
+	case []interface{}:
+		l := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			l = append(l, toRawConfigValue(e, s))
+		}
+		return l
+	case map[string]interface{}:
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok {
+			return v
+		}
+		m := map[string]interface{}{}
+		for key, e := range v {
+			if es, ok := elem.Schema[key]; ok {
+				m[key] = toRawConfigValue(e, es)
+			}
+		}
+		return m
+	default:
+		return v
+	}
+}
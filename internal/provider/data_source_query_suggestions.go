@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func dataSourceQuerySuggestions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for a Query Suggestions configuration. It can be used to reference a configuration created outside Terraform.",
+		ReadContext: dataSourceQuerySuggestionsRead,
+		// https://www.algolia.com/doc/rest-api/query-suggestions/#get-a-configuration
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Query Suggestions index.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      region.US,
+				ValidateFunc: validation.StringInSlice(algoliautil.ValidRegionStrings, false),
+				Description:  `Region of the Query Suggestions index. "us", "eu", "de" are supported. Defaults to "us" when not specified.`,
+			},
+			"source_indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of source indices used to generate the Query Suggestions index.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the source index.",
+						},
+						"analytics_tags": {
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Computed:    true,
+							Description: "A list of analytics tags to filter the popular searches per tag.",
+						},
+						"facets": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Category attribute in your index",
+									},
+									"amount": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "How many of the top categories to show",
+									},
+								},
+							},
+							Description: "A list of facets to define as categories for the query suggestions.",
+						},
+						"min_hits": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum number of hits (e.g., matching records in the source index) to generate a suggestions.",
+						},
+						"min_letters": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum number of required letters for a suggestion to remain.",
+						},
+						"generate": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeList,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+							Description: "List of facet attributes used to generate Query Suggestions.",
+						},
+						"external": {
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Computed:    true,
+							Description: "A list of external indices to use to generate custom Query Suggestions.",
+						},
+					},
+				},
+			},
+			"languages": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "A list of languages used to de-duplicate singular and plural suggestions.",
+			},
+			"exclude": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Computed:    true,
+				Description: "A list of words and patterns to exclude from the Query Suggestions index.",
+			},
+		},
+	}
+}
+
+func dataSourceQuerySuggestionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	suggestionsClient := newSuggestionsClient(d, m)
+
+	indexName := d.Get("index_name").(string)
+	indexConfig, err := suggestionsClient.GetConfig(indexName, ctx)
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			return diag.Errorf("query suggestions index (%s) is not found in region (%s)", indexName, d.Get("region").(string))
+		}
+		return diag.FromErr(algoliautil.HumanizeError(fmt.Errorf("failed to get query suggestions index (%s): %w", indexName, err)))
+	}
+
+	if err := setValues(d, mapToQuerySuggestionsValues(indexConfig)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(indexName)
+
+	return nil
+}
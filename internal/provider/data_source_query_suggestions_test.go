@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceQuerySuggestions_read(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+			_, _ = w.Write([]byte(`{
+  "indexName": "test",
+  "sourceIndices": [
+    {
+      "indexName": "source",
+      "analyticsTags": ["mobile"],
+      "facets": [{"attribute": "brand", "amount": 3}],
+      "minHits": 5,
+      "minLetters": 4,
+      "generate": [["brand"]],
+      "external": ["external_suggestions"]
+    }
+  ],
+  "languages": ["en"],
+  "exclude": ["test"]
+}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceQuerySuggestions().Schema, map[string]interface{}{
+		"index_name": "test",
+	})
+	if diags := dataSourceQuerySuggestionsRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceQuerySuggestionsRead() error = %v", diags)
+	}
+
+	if got := d.Id(); got != "test" {
+		t.Errorf("id = %v, want test", got)
+	}
+	tests := map[string]interface{}{
+		"region":                              "us",
+		"source_indices.0.index_name":         "source",
+		"source_indices.0.facets.0.attribute": "brand",
+		"source_indices.0.facets.0.amount":    3,
+		"source_indices.0.min_hits":           5,
+		"source_indices.0.min_letters":        4,
+		"source_indices.0.generate.0.0":       "brand",
+	}
+	for key, want := range tests {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if got, want := castStringSet(d.Get("source_indices.0.analytics_tags")), []string{"mobile"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("source_indices.0.analytics_tags = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("source_indices.0.external")), []string{"external_suggestions"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("source_indices.0.external = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("languages")), []string{"en"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("languages = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("exclude")), []string{"test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exclude = %v, want %v", got, want)
+	}
+}
+
+func TestDataSourceQuerySuggestions_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Configuration not found","status":404}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceQuerySuggestions().Schema, map[string]interface{}{
+		"index_name": "unknown",
+		"region":     "eu",
+	})
+	diags := dataSourceQuerySuggestionsRead(context.Background(), d, apiClient)
+	if !diags.HasError() || !regexp.MustCompile(`query suggestions index \(unknown\) is not found in region \(eu\)`).MatchString(diags[0].Summary) {
+		t.Errorf("dataSourceQuerySuggestionsRead() error = %v, want not found error", diags)
+	}
+	if got := d.Id(); got != "" {
+		t.Errorf("id = %v, want empty", got)
+	}
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func dataSourceRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for a rule. It can be used to share a rule definition across configurations.",
+		ReadContext: dataSourceRuleRead,
+		// https://www.algolia.com/doc/api-reference/api-methods/get-rule/
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index the rule belongs to.",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier of the rule.",
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of conditions that should apply to activate the rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Query pattern syntax.",
+						},
+						"anchoring": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the pattern parameter must match the beginning or the end of the query string, or both, or none.",
+						},
+						"alternatives": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the `pattern` matches on plurals, synonyms, and typos.",
+						},
+						"context": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule context. The rule is only applied when the same context is specified at query time.",
+						},
+						"filters": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filters to match against the filters of the query.",
+						},
+					},
+				},
+			},
+			"consequence": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Consequence of the rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"params_json": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Additional search parameters in JSON format.",
+						},
+						"promote": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Objects to promote as hits.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"object_ids": {
+										Type:     schema.TypeSet,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+										Computed: true,
+									},
+									"position": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The position to promote the object(s) to (zero-based).",
+									},
+								},
+							},
+						},
+						"filter_promotes": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the promoted objects must match the filters of the query to be promoted.",
+						},
+						"hide": {
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Computed:    true,
+							Description: "List of object IDs to hide from hits.",
+						},
+						"user_data": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Custom JSON formatted string that will be appended to the userData array in the response.",
+						},
+					},
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the rule.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the rule is enabled.",
+			},
+			"validity": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Time ranges when the rule is active.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Lower bound of the time range. RFC3339 format.",
+						},
+						"until": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Upper bound of the time range. RFC3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("index_name").(string)
+	objectID := d.Get("object_id").(string)
+	rule, err := apiClient.searchClient.InitIndex(indexName).GetRule(objectID, ctx)
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			return diag.Errorf("rule (%s) is not found in index (%s)", objectID, indexName)
+		}
+		return diag.FromErr(algoliautil.HumanizeError(fmt.Errorf("failed to get rule (%s) of index (%s): %w", objectID, indexName, err)))
+	}
+
+	values, err := mapToRuleValues(d, indexName, rule, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setValues(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(rule.ObjectID)
+
+	return nil
+}
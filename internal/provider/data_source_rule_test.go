@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRule_read(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/promote-shoes":
+			_, _ = w.Write([]byte(`{
+  "objectID": "promote-shoes",
+  "conditions": [{"pattern": "shoes", "anchoring": "contains", "alternatives": true}],
+  "consequence": {
+    "params": {"filters": "brand:adidas"},
+    "promote": [{"objectIDs": ["1", "2"], "position": 0}],
+    "hide": [{"objectID": "3"}]
+  },
+  "description": "promote shoes",
+  "enabled": false,
+  "validity": [{"from": 1893456000, "until": 1896134400}]
+}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceRule().Schema, map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "promote-shoes",
+	})
+	if diags := dataSourceRuleRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceRuleRead() error = %v", diags)
+	}
+
+	if got := d.Id(); got != "promote-shoes" {
+		t.Errorf("id = %v, want promote-shoes", got)
+	}
+	if got := d.Get("conditions.0.pattern").(string); got != "shoes" {
+		t.Errorf("conditions.0.pattern = %v, want shoes", got)
+	}
+	if got := d.Get("conditions.0.alternatives").(bool); !got {
+		t.Errorf("conditions.0.alternatives = %v, want true", got)
+	}
+	if ok, _ := jsonBytesEqual([]byte(d.Get("consequence.0.params_json").(string)), []byte(`{"filters":"brand:adidas"}`)); !ok {
+		t.Errorf("consequence.0.params_json = %v, want {\"filters\":\"brand:adidas\"}", d.Get("consequence.0.params_json"))
+	}
+	if got, want := castStringSet(d.Get("consequence.0.promote.0.object_ids")), []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("consequence.0.promote.0.object_ids = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("consequence.0.hide")), []string{"3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("consequence.0.hide = %v, want %v", got, want)
+	}
+	if got := d.Get("description").(string); got != "promote shoes" {
+		t.Errorf("description = %v, want promote shoes", got)
+	}
+	if got := d.Get("enabled").(bool); got {
+		t.Errorf("enabled = %v, want false", got)
+	}
+	if got := d.Get("validity.0.from").(string); got != "2030-01-01T00:00:00Z" {
+		t.Errorf("validity.0.from = %v, want 2030-01-01T00:00:00Z", got)
+	}
+}
+
+func TestDataSourceRule_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"ObjectID does not exist","status":404}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceRule().Schema, map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "unknown",
+	})
+	diags := dataSourceRuleRead(context.Background(), d, apiClient)
+	if !diags.HasError() || !regexp.MustCompile(`rule \(unknown\) is not found in index \(test\)`).MatchString(diags[0].Summary) {
+		t.Errorf("dataSourceRuleRead() error = %v, want not found error", diags)
+	}
+}
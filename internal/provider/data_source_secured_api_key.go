@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSecuredAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Description: `Data source to generate a secured API key from a parent API key with the restrictions embedded. To get more information about secured API keys, see the [Official Documentation](https://www.algolia.com/doc/guides/security/api-keys/how-to/user-restricted-access-to-data/).
+
+The key is computed locally without any request to Algolia.
+`,
+		ReadContext: dataSourceSecuredAPIKeyRead,
+		// https://www.algolia.com/doc/api-reference/api-methods/generate-secured-api-key/
+		Schema: map[string]*schema.Schema{
+			"parent_api_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The search-only API key that the secured API key will inherit its restrictions from.",
+			},
+			"filters": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filters applied to all the searches with the key, which can't be overridden by the users.",
+			},
+			"valid_until": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Date and time when the key expires. RFC3339 format. Will not expire per default.",
+			},
+			"restrict_indices": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "List of indices the key is allowed to search. Wildcards (`*`) are supported as prefix and suffix (e.g. `dev_*`).",
+			},
+			"restrict_sources": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "IPv4 network allowed to use the key, in CIDR notation (e.g. `192.168.1.0/24`).",
+			},
+			"referers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "List of referrers allowed to use the key. You can use the “*” (asterisk) character as a wildcard.",
+			},
+			"user_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User identifier used for rate limiting of the key, instead of the IP address.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated secured API key.",
+			},
+		},
+	}
+}
+
+func dataSourceSecuredAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	opts, err := mapToSecuredAPIKeyOpts(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	key, err := search.GenerateSecuredAPIKey(d.Get("parent_api_key").(string), opts...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Use the hash of the key as id not to expose the key.
+	checksum := sha256.Sum256([]byte(key))
+	d.SetId(hex.EncodeToString(checksum[:]))
+	if err := d.Set("key", key); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func mapToSecuredAPIKeyOpts(d *schema.ResourceData) ([]interface{}, error) {
+	var opts []interface{}
+	if v, ok := d.GetOk("filters"); ok {
+		opts = append(opts, opt.Filters(v.(string)))
+	}
+	if v, ok := d.GetOk("valid_until"); ok {
+		validUntil, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt.ValidUntil(validUntil))
+	}
+	if v, ok := d.GetOk("restrict_indices"); ok {
+		opts = append(opts, opt.RestrictIndices(castStringSet(v)...))
+	}
+	if v, ok := d.GetOk("restrict_sources"); ok {
+		opts = append(opts, opt.RestrictSources(v.(string)))
+	}
+	if v, ok := d.GetOk("referers"); ok {
+		opts = append(opts, opt.Referers(castStringSet(v)...))
+	}
+	if v, ok := d.GetOk("user_token"); ok {
+		opts = append(opts, opt.UserToken(v.(string)))
+	}
+	return opts, nil
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSecuredAPIKey_read(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, dataSourceSecuredAPIKey().Schema, map[string]interface{}{
+		"parent_api_key":   "parent",
+		"filters":          "_tags:user_42",
+		"valid_until":      "2030-01-01T09:00:00+09:00",
+		"restrict_indices": []interface{}{"products"},
+		"user_token":       "user_42",
+	})
+	// the key is computed locally, so no API client is needed.
+	if diags := dataSourceSecuredAPIKeyRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("dataSourceSecuredAPIKeyRead() error = %v", diags)
+	}
+	if d.Id() == "" {
+		t.Errorf("id is empty")
+	}
+
+	// The key is the base64 encoding of the HMAC-SHA256 hex checksum (64 characters) followed by the restrictions.
+	decoded, err := base64.StdEncoding.DecodeString(d.Get("key").(string))
+	if err != nil {
+		t.Fatalf("failed to decode key: %v", err)
+	}
+	restrictions, err := url.ParseQuery(string(decoded[64:]))
+	if err != nil {
+		t.Fatalf("failed to parse restrictions: %v", err)
+	}
+	want := map[string]string{
+		"filters":         "_tags:user_42",
+		"validUntil":      "1893456000",
+		"restrictIndices": `["products"]`,
+		"userToken":       "user_42",
+	}
+	for k, v := range want {
+		if got := restrictions.Get(k); got != v {
+			t.Errorf("%s = %v, want %v", k, got, v)
+		}
+	}
+}
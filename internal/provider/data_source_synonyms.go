@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func dataSourceSynonyms() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for all the synonyms of an index. It can be used to consume synonyms managed outside the configuration.",
+		ReadContext: dataSourceSynonymsRead,
+		// https://www.algolia.com/doc/api-reference/api-methods/search-synonyms/
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index to read synonyms from.",
+			},
+			"synonyms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Synonyms of the index.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique identifier for the synonym.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the synonym. Possible values are `synonym`, `oneWaySynonym`, `altCorrection1`, `altCorrection2` and `placeholder`.",
+						},
+						"synonyms": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of synonyms. Set if type=`synonym` or type=`oneWaySynonym`.",
+						},
+						"input": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A word or expression, used as the basis for the array of synonyms. Set if type=`oneWaySynonym`.",
+						},
+						"word": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Single word, used as the basis for the array of corrections. Set if type=`altCorrection1` or type=`altCorrection2`.",
+						},
+						"corrections": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of corrections of the `word`. Set if type=`altCorrection1` or type=`altCorrection2`.",
+						},
+						"placeholder": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Single word, used as the basis for the array of replacements. Set if type=`placeholder`.",
+						},
+						"replacements": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of replacements of the placeholder. Set if type=`placeholder`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSynonymsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("index_name").(string)
+	synonyms, err := listSynonyms(ctx, apiClient, indexName)
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			return diag.Errorf("index (%s) is not found", indexName)
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	d.SetId(indexName)
+	if err := d.Set("synonyms", synonyms); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
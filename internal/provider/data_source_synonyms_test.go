@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSynonyms_read(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/search":
+			_, _ = w.Write([]byte(`{"hits":[
+				{"objectID":"regular","type":"synonym","synonyms":["smartphone","mobile phone"]},
+				{"objectID":"one_way","type":"oneWaySynonym","input":"smartphone","synonyms":["iPhone"]},
+				{"objectID":"alt_correction","type":"altCorrection1","word":"tv","corrections":["television"]},
+				{"objectID":"placeholder","type":"placeholder","placeholder":"<number>","replacements":["1"]}
+			],"nbHits":4}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceSynonyms().Schema, map[string]interface{}{"index_name": "test"})
+	if diags := dataSourceSynonymsRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("dataSourceSynonymsRead() error = %v", diags)
+	}
+
+	if got := d.Id(); got != "test" {
+		t.Errorf("id = %v, want test", got)
+	}
+	got := map[string]map[string]interface{}{}
+	for _, v := range d.Get("synonyms").(*schema.Set).List() {
+		synonym := v.(map[string]interface{})
+		got[synonym["object_id"].(string)] = synonym
+	}
+	if len(got) != 4 {
+		t.Fatalf("synonyms = %v, want 4 synonyms", got)
+	}
+	if got, want := got["one_way"]["input"], "smartphone"; got != want {
+		t.Errorf("input of one_way = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(got["alt_correction"]["corrections"]), []string{"television"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("corrections of alt_correction = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(got["placeholder"]["replacements"]), []string{"1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("replacements of placeholder = %v, want %v", got, want)
+	}
+}
+
+func TestDataSourceSynonyms_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceSynonyms().Schema, map[string]interface{}{"index_name": "unknown"})
+	diags := dataSourceSynonymsRead(context.Background(), d, apiClient)
+	if !diags.HasError() || !regexp.MustCompile(`index \(unknown\) is not found`).MatchString(diags[0].Summary) {
+		t.Errorf("dataSourceSynonymsRead() error = %v, want not found error", diags)
+	}
+}
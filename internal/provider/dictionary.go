@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+// The functions below implement the CRUD of the resources managing a custom entry of a dictionary.
+// Each resource manages a single entry identified by its objectID, so Algolia's standard entries and the entries
+// managed by others are left untouched.
+
+func resourceDictionaryEntryCreate(dictionaryName search.DictionaryName) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		apiClient := m.(*apiClient)
+
+		objectID := d.Get("object_id").(string)
+		if objectID == "" {
+			objectID = fmt.Sprintf("%s-%s", d.Get("language").(string), dictionaryEntryQuery(dictionaryName, d))
+		}
+		if err := algoliautil.SaveDictionaryEntry(ctx, apiClient.searchClient, dictionaryName, mapToDictionaryEntry(dictionaryName, objectID, d)); err != nil {
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+
+		d.SetId(objectID)
+
+		return resourceDictionaryEntryRead(dictionaryName)(ctx, d, m)
+	}
+}
+
+func resourceDictionaryEntryRead(dictionaryName search.DictionaryName) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := refreshDictionaryEntryState(ctx, d, m, dictionaryName); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+}
+
+func resourceDictionaryEntryUpdate(dictionaryName search.DictionaryName) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		apiClient := m.(*apiClient)
+
+		if err := algoliautil.SaveDictionaryEntry(ctx, apiClient.searchClient, dictionaryName, mapToDictionaryEntry(dictionaryName, d.Id(), d)); err != nil {
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+
+		return resourceDictionaryEntryRead(dictionaryName)(ctx, d, m)
+	}
+}
+
+func resourceDictionaryEntryDelete(dictionaryName search.DictionaryName) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		apiClient := m.(*apiClient)
+
+		if err := algoliautil.DeleteDictionaryEntry(ctx, apiClient.searchClient, dictionaryName, d.Id()); err != nil {
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+
+		return nil
+	}
+}
+
+func resourceDictionaryEntryStateContext(dictionaryName search.DictionaryName) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		objectID := d.Id()
+		if err := refreshDictionaryEntryState(ctx, d, m, dictionaryName); err != nil {
+			return nil, err
+		}
+		if d.Id() == "" {
+			return nil, fmt.Errorf("entry (%s) is not found in the %s dictionary", objectID, dictionaryName)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+func refreshDictionaryEntryState(ctx context.Context, d *schema.ResourceData, m interface{}, dictionaryName search.DictionaryName) error {
+	apiClient := m.(*apiClient)
+
+	objectID := d.Id()
+	// The entry is unknown on import, then all the entries are searched.
+	entry, err := algoliautil.FindCustomDictionaryEntry(ctx, apiClient.searchClient, dictionaryName, objectID, dictionaryEntryQuery(dictionaryName, d), d.Get("language").(string))
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		tflog.Warn(ctx, fmt.Sprintf("entry (%s) of the %s dictionary not found, removing from state", objectID, dictionaryName))
+		d.SetId("")
+		return nil
+	}
+
+	if err := setValues(d, flattenDictionaryEntry(dictionaryName, entry)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dictionaryEntryQuery returns the word to search the entry for, which is also used for the default objectID.
+func dictionaryEntryQuery(dictionaryName search.DictionaryName, d *schema.ResourceData) string {
+	if dictionaryName == search.Plurals {
+		if words := castStringList(d.Get("words")); len(words) > 0 {
+			return words[0]
+		}
+		return ""
+	}
+	return d.Get("word").(string)
+}
+
+func mapToDictionaryEntry(dictionaryName search.DictionaryName, objectID string, d *schema.ResourceData) search.DictionaryEntry {
+	language := d.Get("language").(string)
+	switch dictionaryName {
+	case search.Plurals:
+		return search.NewPlural(objectID, language, castStringList(d.Get("words")))
+	case search.Compounds:
+		return search.NewCompound(objectID, language, d.Get("word").(string), castStringList(d.Get("decomposition")))
+	default:
+		return search.NewStopword(objectID, language, d.Get("word").(string), d.Get("state").(string))
+	}
+}
+
+func flattenDictionaryEntry(dictionaryName search.DictionaryName, entry map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{
+		"object_id": entry["objectID"],
+		"language":  entry["language"],
+	}
+	switch dictionaryName {
+	case search.Plurals:
+		values["words"] = entry["words"]
+	case search.Compounds:
+		values["word"] = entry["word"]
+		values["decomposition"] = entry["decomposition"]
+	default:
+		values["word"] = entry["word"]
+		// state isn't returned for the entries saved without it, which are enabled.
+		if state, ok := entry["state"].(string); ok && state != "" {
+			values["state"] = state
+		} else {
+			values["state"] = "enabled"
+		}
+	}
+	return values
+}
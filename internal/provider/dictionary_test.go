@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func Test_mapToDictionaryEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		dictionaryName search.DictionaryName
+		resource       *schema.Resource
+		raw            map[string]interface{}
+		want           string
+	}{
+		{
+			name:           "stopword",
+			dictionaryName: search.Stopwords,
+			resource:       resourceDictionaryStopwords(),
+			raw:            map[string]interface{}{"language": "en", "word": "the", "state": "disabled"},
+			want:           `{"objectID":"test","language":"en","word":"the","state":"disabled"}`,
+		},
+		{
+			name:           "plural",
+			dictionaryName: search.Plurals,
+			resource:       resourceDictionaryPlurals(),
+			raw:            map[string]interface{}{"language": "en", "words": []interface{}{"mouse", "mice"}},
+			want:           `{"objectID":"test","language":"en","words":["mouse","mice"]}`,
+		},
+		{
+			name:           "compound",
+			dictionaryName: search.Compounds,
+			resource:       resourceDictionaryCompounds(),
+			raw:            map[string]interface{}{"language": "de", "word": "kopfschmerz", "decomposition": []interface{}{"kopf", "schmerz"}},
+			want:           `{"objectID":"test","language":"de","word":"kopfschmerz","decomposition":["kopf","schmerz"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tt.resource.Schema, tt.raw)
+
+			got, err := json.Marshal(mapToDictionaryEntry(tt.dictionaryName, "test", d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, _ := jsonBytesEqual(got, []byte(tt.want)); !ok {
+				t.Errorf("mapToDictionaryEntry() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenDictionaryEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		dictionaryName search.DictionaryName
+		entry          string
+		want           map[string]interface{}
+	}{
+		{
+			name:           "stopword without state",
+			dictionaryName: search.Stopwords,
+			entry:          `{"objectID":"test","language":"en","word":"the","type":"custom"}`,
+			want:           map[string]interface{}{"object_id": "test", "language": "en", "word": "the", "state": "enabled"},
+		},
+		{
+			name:           "plural",
+			dictionaryName: search.Plurals,
+			entry:          `{"objectID":"test","language":"en","words":["mouse","mice"],"type":"custom"}`,
+			want:           map[string]interface{}{"object_id": "test", "language": "en", "words": []interface{}{"mouse", "mice"}},
+		},
+		{
+			name:           "compound",
+			dictionaryName: search.Compounds,
+			entry:          `{"objectID":"test","language":"de","word":"kopfschmerz","decomposition":["kopf","schmerz"],"type":"custom"}`,
+			want:           map[string]interface{}{"object_id": "test", "language": "de", "word": "kopfschmerz", "decomposition": []interface{}{"kopf", "schmerz"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.entry), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if got := flattenDictionaryEntry(tt.dictionaryName, entry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenDictionaryEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testAccCheckDictionaryEntryDestroy(resourceType string, dictionaryName search.DictionaryName) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := newTestAPIClient()
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			entry, err := algoliautil.FindCustomDictionaryEntry(context.Background(), apiClient.searchClient, dictionaryName, rs.Primary.ID, "", rs.Primary.Attributes["language"])
+			if err != nil {
+				return err
+			}
+			if entry != nil {
+				return fmt.Errorf("entry '%s' of the %s dictionary still exists", rs.Primary.ID, dictionaryName)
+			}
+		}
+
+		return nil
+	}
+}
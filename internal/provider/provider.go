@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/analytics"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/personalization"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/suggestions"
@@ -10,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
 	"github.com/hashicorp/terraform-provider-algolia/internal/mutex"
 )
@@ -39,18 +42,46 @@ func New(version string) func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("ALGOLIA_API_KEY", nil),
 					Description: "The API key to access algolia resources. Defaults to the env variable `ALGOLIA_API_KEY`.",
 				},
+				"wait_for_task": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to wait for the settings update task of `algolia_index` to be published. It can be overridden by `wait_for_task` of each `algolia_index`. Defaults to true.",
+				},
+				"personalization_region": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      region.US,
+					ValidateFunc: validation.StringInSlice(algoliautil.ValidRegionStrings, false),
+					Description:  "Region of the personalization strategy of the application. It's used by `algolia_index` to check whether a strategy is configured when `enable_personalization` is true. Defaults to `us`.",
+				},
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"algolia_index":             resourceIndex(),
-				"algolia_virtual_index":     resourceVirtualIndex(),
-				"algolia_api_key":           resourceAPIKey(),
-				"algolia_rule":              resourceRule(),
-				"algolia_synonyms":          resourceSynonyms(),
-				"algolia_query_suggestions": resourceQuerySuggestions(),
+				"algolia_index":                    resourceIndex(),
+				"algolia_index_clear":              resourceIndexClear(),
+				"algolia_index_bundle":             resourceIndexBundle(),
+				"algolia_virtual_index":            resourceVirtualIndex(),
+				"algolia_localized_indices":        resourceLocalizedIndices(),
+				"algolia_api_key":                  resourceAPIKey(),
+				"algolia_rule":                     resourceRule(),
+				"algolia_rules":                    resourceRules(),
+				"algolia_synonyms":                 resourceSynonyms(),
+				"algolia_synonym":                  resourceSynonym(),
+				"algolia_dictionary_stopwords":     resourceDictionaryStopwords(),
+				"algolia_dictionary_plurals":       resourceDictionaryPlurals(),
+				"algolia_dictionary_compounds":     resourceDictionaryCompounds(),
+				"algolia_query_suggestions":        resourceQuerySuggestions(),
+				"algolia_ab_test":                  resourceABTest(),
+				"algolia_personalization_strategy": resourcePersonalizationStrategy(),
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"algolia_index":         dataSourceIndex(),
-				"algolia_virtual_index": dataSourceVirtualIndex(),
+				"algolia_index":             dataSourceIndex(),
+				"algolia_virtual_index":     dataSourceVirtualIndex(),
+				"algolia_api_key":           dataSourceAPIKey(),
+				"algolia_secured_api_key":   dataSourceSecuredAPIKey(),
+				"algolia_rule":              dataSourceRule(),
+				"algolia_synonyms":          dataSourceSynonyms(),
+				"algolia_query_suggestions": dataSourceQuerySuggestions(),
 			},
 		}
 		p.ConfigureContextFunc = configure(version, p)
@@ -64,8 +95,58 @@ type apiClient struct {
 	appID     string
 	apiKey    string
 	requester transport.Requester
+	// waitForTask is the default of whether to wait for the settings update task to be published.
+	waitForTask bool
+	// personalizationRegion is the region of the personalization strategy of the application.
+	personalizationRegion region.Region
+
+	searchClient searchClient
+}
+
+// searchClient is the subset of *search.Client used by the provider, so that tests can inject a fake.
+type searchClient interface {
+	InitIndex(indexName string) searchIndex
+	GetAPIKey(keyID string, opts ...interface{}) (search.Key, error)
+	AddAPIKey(key search.Key, opts ...interface{}) (search.CreateKeyRes, error)
+	UpdateAPIKey(key search.Key, opts ...interface{}) (search.UpdateKeyRes, error)
+	DeleteAPIKey(keyID string, opts ...interface{}) (search.DeleteKeyRes, error)
+	SaveDictionaryEntries(dictionaryName search.DictionaryName, dictionaryEntries []search.DictionaryEntry, opts ...interface{}) (search.UpdateTaskRes, error)
+	SearchDictionaryEntries(dictionaryName search.DictionaryName, query string, opts ...interface{}) (search.SearchDictionariesRes, error)
+	DeleteDictionaryEntries(dictionaryName search.DictionaryName, objectIDs []string, opts ...interface{}) (search.UpdateTaskRes, error)
+}
+
+// searchIndex is the subset of *search.Index used by the provider, so that tests can fake index, rule and synonym operations.
+type searchIndex interface {
+	GetName() string
+	Exists() (bool, error)
+	Delete(opts ...interface{}) (search.DeleteTaskRes, error)
+	GetSettings(opts ...interface{}) (search.Settings, error)
+	SetSettings(settings search.Settings, opts ...interface{}) (search.UpdateTaskRes, error)
+	GetObject(objectID string, object interface{}, opts ...interface{}) error
+	SaveObjects(objects interface{}, opts ...interface{}) (search.GroupBatchRes, error)
+	ClearObjects(opts ...interface{}) (search.UpdateTaskRes, error)
+	GetRule(objectID string, opts ...interface{}) (search.Rule, error)
+	SaveRule(rule search.Rule, opts ...interface{}) (search.UpdateTaskRes, error)
+	SaveRules(rules []search.Rule, opts ...interface{}) (search.UpdateTaskRes, error)
+	BrowseRules(opts ...interface{}) (*search.RuleIterator, error)
+	DeleteRule(objectID string, opts ...interface{}) (search.UpdateTaskRes, error)
+	ClearRules(opts ...interface{}) (search.UpdateTaskRes, error)
+	GetSynonym(objectID string, opts ...interface{}) (search.Synonym, error)
+	SaveSynonym(synonym search.Synonym, opts ...interface{}) (search.UpdateTaskRes, error)
+	SearchSynonyms(query string, opts ...interface{}) (search.SearchSynonymsRes, error)
+	BrowseSynonyms(opts ...interface{}) (*search.SynonymIterator, error)
+	ReplaceAllSynonyms(synonyms []search.Synonym, opts ...interface{}) (search.UpdateTaskRes, error)
+	DeleteSynonym(objectID string, opts ...interface{}) (search.DeleteTaskRes, error)
+	ClearSynonyms(opts ...interface{}) (search.UpdateTaskRes, error)
+}
 
-	searchClient *search.Client
+// algoliaSearchClient adapts *search.Client to searchClient.
+type algoliaSearchClient struct {
+	*search.Client
+}
+
+func (c algoliaSearchClient) InitIndex(indexName string) searchIndex {
+	return c.Client.InitIndex(indexName)
 }
 
 func (a *apiClient) newSuggestionsClient(region region.Region) *suggestions.Client {
@@ -78,11 +159,54 @@ func (a *apiClient) newSuggestionsClient(region region.Region) *suggestions.Clie
 	})
 }
 
+func (a *apiClient) newAnalyticsClient(region region.Region) *analytics.Client {
+	return analytics.NewClientWithConfig(analytics.Configuration{
+		AppID:          a.appID,
+		APIKey:         a.apiKey,
+		Region:         region,
+		ExtraUserAgent: a.userAgent,
+		Requester:      a.requester,
+	})
+}
+
+func (a *apiClient) newPersonalizationClient(region region.Region) *personalization.Client {
+	return personalization.NewClientWithConfig(personalization.Configuration{
+		AppID:          a.appID,
+		APIKey:         a.apiKey,
+		Region:         region,
+		ExtraUserAgent: a.userAgent,
+		Requester:      a.requester,
+	})
+}
+
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// The credentials may be unknown at plan time when they refer to other resources, then they're checked on apply.
+		appID := d.Get("app_id").(string)
+		if appID == "" && isConfigKnown(d, "app_id") {
+			return nil, diag.Errorf("`app_id` must be set in the provider configuration or the env variable `ALGOLIA_APP_ID`")
+		}
+		apiKey := d.Get("api_key").(string)
+		if apiKey == "" && isConfigKnown(d, "api_key") {
+			return nil, diag.Errorf("`api_key` must be set in the provider configuration or the env variable `ALGOLIA_API_KEY`")
+		}
+
 		userAgent := p.UserAgent("terraform-provider-algolia", version)
-		return newAPIClient(d.Get("app_id").(string), d.Get("api_key").(string), userAgent), nil
+		client := newAPIClient(appID, apiKey, userAgent)
+		client.waitForTask = d.Get("wait_for_task").(bool)
+		client.personalizationRegion = region.Region(d.Get("personalization_region").(string))
+		return client, nil
+	}
+}
+
+// isConfigKnown returns whether the value of the provider configuration is known, which is not the case at plan time
+// when it refers to an attribute of a resource to be created.
+func isConfigKnown(d *schema.ResourceData, key string) bool {
+	v := d.GetRawConfig()
+	if !v.IsKnown() {
+		return false
 	}
+	return v.IsNull() || v.GetAttr(key).IsKnown()
 }
 
 func newAPIClient(appID, apiKey, userAgent string) *apiClient {
@@ -97,13 +221,15 @@ func newAPIClient(appID, apiKey, userAgent string) *apiClient {
 		ExtraUserAgent: userAgent,
 		Requester:      algoliaRequester,
 	}
-	searchClient := search.NewClientWithConfig(searchConfig)
+	searchClient := algoliaSearchClient{search.NewClientWithConfig(searchConfig)}
 
 	return &apiClient{
-		appID:        appID,
-		apiKey:       apiKey,
-		userAgent:    userAgent,
-		requester:    algoliaRequester,
-		searchClient: searchClient,
+		appID:                 appID,
+		apiKey:                apiKey,
+		userAgent:             userAgent,
+		requester:             algoliaRequester,
+		waitForTask:           true,
+		personalizationRegion: region.US,
+		searchClient:          searchClient,
 	}
 }
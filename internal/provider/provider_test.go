@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // providerFactories are used to instantiate a provider during acceptance testing.
@@ -38,3 +43,82 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("env variable 'ALGOLIA_API_KEY' is not set")
 	}
 }
+
+func TestProvider_configure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  map[string]cty.Value
+		wantErr string
+	}{
+		{
+			name:   "app_id and api_key",
+			config: map[string]cty.Value{"app_id": cty.StringVal("test"), "api_key": cty.StringVal("test")},
+		},
+		{
+			name:    "empty app_id",
+			config:  map[string]cty.Value{"app_id": cty.StringVal(""), "api_key": cty.StringVal("test")},
+			wantErr: "`app_id` must be set",
+		},
+		{
+			name:    "empty api_key",
+			config:  map[string]cty.Value{"app_id": cty.StringVal("test"), "api_key": cty.StringVal("")},
+			wantErr: "`api_key` must be set",
+		},
+		{
+			name:   "unknown app_id and api_key at plan time",
+			config: map[string]cty.Value{"app_id": cty.UnknownVal(cty.String), "api_key": cty.UnknownVal(cty.String)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestAlgoliaProvider()
+			configSchema := schema.InternalMap(p.Schema).CoreConfigSchema()
+			config := map[string]cty.Value{}
+			for name, attr := range configSchema.Attributes {
+				config[name] = cty.NullVal(attr.Type)
+			}
+			for name, v := range tt.config {
+				config[name] = v
+			}
+			// The raw config is set as well as the provider server does.
+			c := terraform.NewResourceConfigShimmed(cty.ObjectVal(config), configSchema)
+			c.CtyValue = cty.ObjectVal(config)
+			diags := p.Configure(context.Background(), c)
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Errorf("Configure() error = %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.wantErr) {
+				t.Errorf("Configure() error = %v, want %s", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProvider_humanizeAuthError(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Method not allowed with this API key","status":403}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "shoes",
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"shoes"}`}},
+	})
+	d.SetId("shoes")
+
+	diags := resourceRuleRead(context.Background(), d, apiClient)
+	if !diags.HasError() || strings.Count(diags[0].Summary, "check that `app_id` and `api_key` are correct") != 1 {
+		t.Errorf("resourceRuleRead() error = %v, want auth hint once", diags)
+	}
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/analytics"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourceABTest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceABTestCreate,
+		ReadContext:   resourceABTestRead,
+		DeleteContext: resourceABTestDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceABTestStateContext,
+		},
+		CustomizeDiff: validateABTestTrafficPercentages,
+		Description: `A configuration for an A/B test. To get more information about A/B testing, see the [Official Documentation](https://www.algolia.com/doc/guides/ab-testing/what-is-ab-testing/).
+
+※ A/B tests can't be updated, so any change deletes the A/B test and creates a new one, and the results of the A/B test are lost.
+`,
+		// https://www.algolia.com/doc/api-reference/api-methods/add-ab-test/
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the A/B test.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      region.US,
+				ValidateFunc: validation.StringInSlice(algoliautil.ValidRegionStrings, false),
+				Description:  `Region of the analytics to run the A/B test in. "us", "eu", "de" are supported. Defaults to "us" when not specified.`,
+			},
+			"variants": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    2,
+				MaxItems:    2,
+				Description: "The two variants of the A/B test. The first one is the control variant (A).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Name of the index to target.",
+						},
+						"traffic_percentage": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(1, 99),
+							Description:  "Percentage of the traffic that should be going to the variant. The sum of the percentages of the variants must be 100.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Description of the variant.",
+						},
+						"custom_search_parameters_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: diffJsonSuppress,
+							ValidateFunc:     validation.StringIsJSON,
+							Description:      "Search parameters in JSON format applied to the variant, which allows to A/B test the parameters on the same index. Only the search parameters known to the Algolia API client are supported.",
+						},
+					},
+				},
+			},
+			"end_at": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Date and time when the A/B test ends. RFC3339 format.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the A/B test. Possible values are `active`, `stopped`, `expired` and `failed`.",
+			},
+		},
+	}
+}
+
+func resourceABTestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	analyticsClient := newAnalyticsClient(d, m)
+
+	abTest, err := mapToABTest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := analyticsClient.AddABTest(abTest, ctx)
+	if err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	if err := res.Wait(); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	d.SetId(strconv.Itoa(res.ABTestID))
+
+	return resourceABTestRead(ctx, d, m)
+}
+
+func resourceABTestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshABTestState(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceABTestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	analyticsClient := newAnalyticsClient(d, m)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := analyticsClient.DeleteABTest(id, ctx)
+	if err != nil {
+		// The A/B test may have been deleted out of band.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	if err := res.Wait(); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return nil
+}
+
+func resourceABTestStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	r, id, err := parseImportRegionAndId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if r != "" {
+		if err := d.Set("region", string(r)); err != nil {
+			return nil, err
+		}
+	}
+	d.SetId(id)
+	if err := refreshABTestState(ctx, d, m); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func refreshABTestState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	analyticsClient := newAnalyticsClient(d, m)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("A/B test ID must be numeric: %w", err)
+	}
+
+	var abTest analytics.ABTestResponse
+	err = retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
+		var err error
+		abTest, err = analyticsClient.GetABTest(id, ctx)
+
+		if d.IsNewResource() && algoliautil.IsRetryableError(err) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("A/B test (%s) not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return algoliautil.HumanizeError(err)
+	}
+
+	var variants []interface{}
+	for _, variant := range abTest.Variants {
+		var customSearchParametersJSON string
+		if variant.CustomSearchParameters != nil {
+			b, err := json.Marshal(variant.CustomSearchParameters)
+			if err != nil {
+				return err
+			}
+			customSearchParametersJSON = string(b)
+		}
+		variants = append(variants, map[string]interface{}{
+			"index_name":                    variant.Index,
+			"traffic_percentage":            variant.TrafficPercentage,
+			"description":                   variant.Description,
+			"custom_search_parameters_json": customSearchParametersJSON,
+		})
+	}
+
+	endAt := abTest.EndAt.UTC().Format(time.RFC3339)
+	// keep the configured format (e.g. with a time zone offset) as long as it's the same time.
+	if configured, err := time.Parse(time.RFC3339, d.Get("end_at").(string)); err == nil && configured.Equal(abTest.EndAt) {
+		endAt = d.Get("end_at").(string)
+	}
+
+	values := map[string]interface{}{
+		"name":     abTest.Name,
+		"variants": variants,
+		"end_at":   endAt,
+		"status":   abTest.Status,
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateABTestTrafficPercentages returns an error if the sum of the traffic percentages of the variants isn't 100.
+func validateABTestTrafficPercentages(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("variants") {
+		return nil
+	}
+	var sum int
+	for _, v := range d.Get("variants").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		sum += v.(map[string]interface{})["traffic_percentage"].(int)
+	}
+	if sum != 100 {
+		return fmt.Errorf("the sum of `traffic_percentage` of the variants must be 100, got %d", sum)
+	}
+	return nil
+}
+
+func mapToABTest(d *schema.ResourceData) (analytics.ABTest, error) {
+	endAt, err := time.Parse(time.RFC3339, d.Get("end_at").(string))
+	if err != nil {
+		return analytics.ABTest{}, err
+	}
+
+	abTest := analytics.ABTest{
+		Name:  d.Get("name").(string),
+		EndAt: endAt,
+	}
+	for _, v := range d.Get("variants").([]interface{}) {
+		variantMap := v.(map[string]interface{})
+		variant := analytics.Variant{
+			Index:             variantMap["index_name"].(string),
+			TrafficPercentage: variantMap["traffic_percentage"].(int),
+			Description:       variantMap["description"].(string),
+		}
+		if s := variantMap["custom_search_parameters_json"].(string); s != "" {
+			var params search.QueryParams
+			if err := json.Unmarshal([]byte(s), &params); err != nil {
+				return analytics.ABTest{}, fmt.Errorf("failed to unmarshal custom_search_parameters_json of variant (%s): %w", variant.Index, err)
+			}
+			variant.CustomSearchParameters = &params
+		}
+		abTest.Variants = append(abTest.Variants, variant)
+	}
+
+	return abTest, nil
+}
+
+func newAnalyticsClient(d *schema.ResourceData, m interface{}) *analytics.Client {
+	apiClient := m.(*apiClient)
+	r := region.Region(d.Get("region").(string))
+	return apiClient.newAnalyticsClient(r)
+}
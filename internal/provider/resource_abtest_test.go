@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceABTest(t *testing.T) {
+	indexName := randResourceID(80)
+	resourceName := fmt.Sprintf("algolia_ab_test.%s", indexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceABTest(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^\d+$`)),
+					resource.TestCheckResourceAttr(resourceName, "name", indexName),
+					resource.TestCheckResourceAttr(resourceName, "variants.0.index_name", indexName),
+					resource.TestCheckResourceAttr(resourceName, "variants.0.traffic_percentage", "60"),
+					resource.TestCheckResourceAttr(resourceName, "variants.1.index_name", indexName+"_b"),
+					resource.TestCheckResourceAttr(resourceName, "variants.1.traffic_percentage", "40"),
+					resource.TestCheckResourceAttr(resourceName, "end_at", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "status", "active"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckABTestDestroy,
+	})
+}
+
+func TestResourceABTest_validateABTestTrafficPercentages(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"variants": []interface{}{
+			map[string]interface{}{"index_name": "test_a", "traffic_percentage": 60},
+			map[string]interface{}{"index_name": "test_b", "traffic_percentage": 50},
+		},
+		"end_at": "2030-01-01T00:00:00Z",
+	}
+	_, err := testResourceDiff(resourceABTest(), raw)
+	if err == nil || !regexp.MustCompile("must be 100, got 110").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want traffic percentage error", err)
+	}
+}
+
+func TestResourceABTest_createAndRead(t *testing.T) {
+	t.Parallel()
+
+	var abTest []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/2/abtests":
+			abTest, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"abTestID":42,"index":"test_a","taskID":1}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test_a/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/2/abtests/42":
+			_, _ = w.Write([]byte(`{
+				"abTestID":42,"name":"test","status":"active","endAt":"2030-01-01T00:00:00Z",
+				"variants":[
+					{"index":"test_a","trafficPercentage":60,"description":"control"},
+					{"index":"test_a","trafficPercentage":40,"customSearchParameters":{"ignorePlurals":true}}
+				]
+			}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceABTest().Schema, map[string]interface{}{
+		"name": "test",
+		"variants": []interface{}{
+			map[string]interface{}{"index_name": "test_a", "traffic_percentage": 60, "description": "control"},
+			map[string]interface{}{"index_name": "test_a", "traffic_percentage": 40, "custom_search_parameters_json": `{"ignorePlurals": true}`},
+		},
+		"end_at": "2030-01-01T09:00:00+09:00",
+	})
+
+	if diags := resourceABTestCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceABTestCreate() error = %v", diags)
+	}
+
+	want := `{"name":"test","variants":[{"index":"test_a","trafficPercentage":60,"description":"control"},{"index":"test_a","trafficPercentage":40,"customSearchParameters":{"ignorePlurals":true}}],"endAt":"2030-01-01T00:00:00Z"}`
+	if ok, _ := jsonBytesEqual(abTest, []byte(want)); !ok {
+		t.Errorf("A/B test = %s, want %s", abTest, want)
+	}
+	if got := d.Id(); got != "42" {
+		t.Errorf("id = %v, want 42", got)
+	}
+	// the configured time zone is kept since it's the same time.
+	if got := d.Get("end_at").(string); got != "2030-01-01T09:00:00+09:00" {
+		t.Errorf("end_at = %v, want 2030-01-01T09:00:00+09:00", got)
+	}
+	if got := d.Get("variants.1.custom_search_parameters_json").(string); got != `{"ignorePlurals":true}` {
+		t.Errorf("variants.1.custom_search_parameters_json = %v, want {\"ignorePlurals\":true}", got)
+	}
+	if got := d.Get("status").(string); got != "active" {
+		t.Errorf("status = %v, want active", got)
+	}
+}
+
+func TestResourceABTest_readDeletedABTest(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/2/abtests/42":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"ABTestID not found","status":404}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceABTest().Schema, map[string]interface{}{})
+	d.SetId("42")
+
+	if diags := resourceABTestRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceABTestRead() error = %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %v, want empty", d.Id())
+	}
+}
+
+func testAccResourceABTest(indexName string) string {
+	return `
+resource "algolia_index" "` + indexName + `" {
+  name                = "` + indexName + `"
+  deletion_protection = false
+}
+
+resource "algolia_index" "` + indexName + `_b" {
+  name                = "` + indexName + `_b"
+  deletion_protection = false
+}
+
+resource "algolia_ab_test" "` + indexName + `" {
+  name = "` + indexName + `"
+
+  variants {
+    index_name         = algolia_index.` + indexName + `.name
+    traffic_percentage = 60
+  }
+  variants {
+    index_name         = algolia_index.` + indexName + `_b.name
+    traffic_percentage = 40
+  }
+
+  end_at = "2030-01-01T00:00:00Z"
+}
+`
+}
+
+func testAccCheckABTestDestroy(s *terraform.State) error {
+	apiClient := newTestAPIClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "algolia_ab_test" {
+			continue
+		}
+
+		d := schema.TestResourceDataRaw(&testing.T{}, resourceABTest().Schema, map[string]interface{}{"region": rs.Primary.Attributes["region"]})
+		d.SetId(rs.Primary.ID)
+		if err := refreshABTestState(context.Background(), d, apiClient); err != nil {
+			return err
+		}
+		if d.Id() != "" {
+			return fmt.Errorf("A/B test '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -2,13 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/transport"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
@@ -23,21 +31,26 @@ func resourceAPIKey() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceAPIKeyStateContext,
 		},
-		Description: "A configuration for an API key",
+		CustomizeDiff: customdiff.All(
+			validateACLNotEmpty,
+			warnOverlyPermissiveAPIKey,
+			validateExpiresAtInFuture,
+		),
+		Description: "A configuration for an API key. A key having broad ACLs on all indices is reported as a warning only in the provider logs at plan time, which are shown with `TF_LOG=WARN`.",
 		// https://www.algolia.com/doc/api-reference/api-methods/add-api-key/
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
-				Description: "The created key.",
+				Description: "The created key. It's marked as sensitive and never logged, but it's still stored in plaintext in the state, so make sure the state is stored securely.",
 			},
 			"acl": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 				Required: true,
-				Description: `Set of permissions associated with the key.
+				Description: `Set of permissions associated with the key. At least one permission is required.
 The possible ACLs are:
   - ` + "`search`" + `: allowed to perform search operations.
   - ` + "`browse`" + `: allowed to retrieve all index data with the browse endpoint.
@@ -59,7 +72,7 @@ The possible ACLs are:
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.IsRFC3339Time,
-				Description:  "Unix timestamp of the date at which the key expires. RFC3339 format. Will not expire per default.",
+				Description:  "Unix timestamp of the date at which the key expires. RFC3339 format. It must be in the future when it's set or changed. Will not expire per default.",
 			},
 			"max_hits_per_query": {
 				Type:        schema.TypeInt,
@@ -94,6 +107,19 @@ This parameter can be used to protect you from attempts at retrieving your entir
 				Optional:    true,
 				Description: "Description of the API key.",
 			},
+			"query_parameters": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAPIKeyQueryParameters,
+				DiffSuppressFunc: diffAPIKeyQueryParametersSuppress,
+				Description:      "URL-encoded query parameters which are forced on the searches performed with the key, e.g. `filters=brand%3Aapple&hitsPerPage=10`. They can't be overridden at query time. Use `restrict_sources` for `restrictSources`.",
+			},
+			"restrict_sources": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPv4CIDR,
+				Description:  "IPv4 network allowed to use the key, in CIDR notation (e.g. `192.168.1.0/24`). The requests from the other sources are rejected.",
+			},
 			"created_at": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -108,10 +134,10 @@ func resourceAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	res, err := apiClient.searchClient.AddAPIKey(mapToAPIKey(d), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	if err := d.Set("key", res.Key); err != nil {
@@ -133,10 +159,10 @@ func resourceAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	res, err := apiClient.searchClient.UpdateAPIKey(mapToAPIKey(d), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return resourceAPIKeyRead(ctx, d, m)
@@ -147,10 +173,10 @@ func resourceAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interfa
 
 	res, err := apiClient.searchClient.DeleteAPIKey(d.Get("key").(string), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
@@ -175,16 +201,31 @@ func refreshAPIKeyState(ctx context.Context, d *schema.ResourceData, m interface
 	key, err := apiClient.searchClient.GetAPIKey(keyID, ctx)
 	if err != nil {
 		if algoliautil.IsNotFoundError(err) {
-			tflog.Warn(ctx, fmt.Sprintf("api key (%s) not found, removing from state", d.Id()))
+			tflog.Warn(ctx, fmt.Sprintf("api key (%s) not found, removing from state", algoliautil.MaskAPIKey(keyID)))
 			d.SetId("")
 			return nil
 		}
-		return err
+		return algoliautil.HumanizeError(err)
 	}
 
 	d.SetId(strconv.FormatInt(key.CreatedAt.Unix(), 10))
 
-	values := map[string]interface{}{
+	values := mapToAPIKeyValues(keyID, key)
+	configuredExpiresAt := d.Get("expires_at").(string)
+	// The key which never expires is left unset unless `expires_at` has been set, not to add an empty value to the state.
+	if key.Validity > 0 || configuredExpiresAt != "" {
+		values["expires_at"] = flattenAPIKeyExpiresAt(key.Validity, configuredExpiresAt, time.Now())
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mapToAPIKeyValues maps the key to the values shared by the resource and the data source.
+func mapToAPIKeyValues(keyID string, key search.Key) map[string]interface{} {
+	return map[string]interface{}{
 		"key":                         keyID,
 		"acl":                         key.ACL,
 		"max_hits_per_query":          key.MaxHitsPerQuery,
@@ -192,18 +233,10 @@ func refreshAPIKeyState(ctx context.Context, d *schema.ResourceData, m interface
 		"referers":                    key.Referers,
 		"description":                 key.Description,
 		"indexes":                     key.Indexes,
+		"query_parameters":            flattenAPIKeyQueryParameters(key.QueryParameters),
+		"restrict_sources":            key.QueryParameters.RestrictSources.Get(),
 		"created_at":                  key.CreatedAt.Unix(),
 	}
-	// we can't set from key.Validity since it is remaining valid time and the value changes every second.
-	// TODO: fix to work with import
-	if expiresAtRFC3339, ok := d.GetOk("expires_at"); ok {
-		values["expires_at"] = expiresAtRFC3339
-	}
-	if err := setValues(d, values); err != nil {
-		return err
-	}
-
-	return nil
 }
 
 func mapToAPIKey(d *schema.ResourceData) search.Key {
@@ -213,6 +246,15 @@ func mapToAPIKey(d *schema.ResourceData) search.Key {
 		validity = time.Duration(int(t.Unix())-int(time.Now().Unix())) * time.Second
 	}
 
+	var queryParameters search.KeyQueryParams
+	if v, ok := d.GetOk("query_parameters"); ok {
+		// the format is validated by the schema.
+		queryParameters, _ = unmarshalAPIKeyQueryParameters(v.(string))
+	}
+	if v, ok := d.GetOk("restrict_sources"); ok {
+		queryParameters.RestrictSources = opt.RestrictSources(v.(string))
+	}
+
 	return search.Key{
 		Value:                  d.Get("key").(string),
 		ACL:                    castStringSet(d.Get("acl")),
@@ -222,5 +264,174 @@ func mapToAPIKey(d *schema.ResourceData) search.Key {
 		Indexes:                castStringSet(d.Get("indexes")),
 		Referers:               castStringSet(d.Get("referers")),
 		Description:            d.Get("description").(string),
+		QueryParameters:        queryParameters,
+	}
+}
+
+// apiKeyExpiresAtTolerance is the max difference between the configured `expires_at` and the one computed from the validity
+// to consider them the same. It absorbs the latency between the requests, since the validity is computed from the request time.
+const apiKeyExpiresAtTolerance = 1 * time.Minute
+
+// flattenAPIKeyExpiresAt converts the remaining validity of the key at now to `expires_at`. `0` means the key never expires.
+// The configured value is kept if it's within the tolerance, otherwise the computed time is rounded to the minute
+// so that the value doesn't change on every read.
+func flattenAPIKeyExpiresAt(validity time.Duration, configured string, now time.Time) string {
+	if validity <= 0 {
+		return ""
+	}
+	expiresAt := now.Add(validity)
+	if configuredExpiresAt, err := time.Parse(time.RFC3339, configured); err == nil {
+		if diff := expiresAt.Sub(configuredExpiresAt); diff > -apiKeyExpiresAtTolerance && diff < apiKeyExpiresAtTolerance {
+			return configured
+		}
+	}
+	return expiresAt.Round(time.Minute).UTC().Format(time.RFC3339)
+}
+
+func unmarshalAPIKeyQueryParameters(queryParameters string) (search.KeyQueryParams, error) {
+	var params search.KeyQueryParams
+	if queryParameters == "" {
+		return params, nil
+	}
+	if err := transport.URLDecode([]byte(queryParameters), &params); err != nil {
+		return params, err
+	}
+	return params, nil
+}
+
+// flattenAPIKeyQueryParameters encodes the query parameters of the key except `restrictSources`, which is read as `restrict_sources`.
+func flattenAPIKeyQueryParameters(params search.KeyQueryParams) string {
+	params.RestrictSources = nil
+	return transport.URLEncode(params)
+}
+
+// normalizeAPIKeyQueryParameters returns the query parameters encoded in the same way as the ones returned by Algolia.
+func normalizeAPIKeyQueryParameters(queryParameters string) (string, error) {
+	params, err := unmarshalAPIKeyQueryParameters(queryParameters)
+	if err != nil {
+		return "", err
+	}
+	return transport.URLEncode(params), nil
+}
+
+// validateAPIKeyQueryParameters returns an error if the query parameters can't be parsed or contain the parameters
+// which the API client drops, since they would never be applied and cause a diff on every plan.
+func validateAPIKeyQueryParameters(v interface{}, k string) ([]string, []error) {
+	queryParameters := v.(string)
+	values, err := url.ParseQuery(queryParameters)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be URL-encoded query parameters: %w", k, err)}
+	}
+	normalized, err := normalizeAPIKeyQueryParameters(queryParameters)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be URL-encoded query parameters: %w", k, err)}
+	}
+	normalizedValues, _ := url.ParseQuery(normalized)
+	if _, ok := values["restrictSources"]; ok {
+		return nil, []error{fmt.Errorf("%q can't contain restrictSources, use `restrict_sources` instead", k)}
+	}
+
+	var unsupported []string
+	for name := range values {
+		if _, ok := normalizedValues[name]; !ok {
+			unsupported = append(unsupported, name)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return nil, []error{fmt.Errorf("%q has unsupported or empty query parameters: %s", k, strings.Join(unsupported, ", "))}
+	}
+	return nil, nil
+}
+
+// validateIPv4CIDR validates that the value is an IPv4 network in CIDR notation, since Algolia rejects IPv6 for `restrictSources`.
+func validateIPv4CIDR(v interface{}, k string) ([]string, []error) {
+	if _, errs := validation.IsCIDR(v, k); len(errs) > 0 {
+		return nil, errs
+	}
+	if ip, _, _ := net.ParseCIDR(v.(string)); ip.To4() == nil {
+		return nil, []error{fmt.Errorf("expected %q to be an IPv4 network, got %v", k, v)}
+	}
+	return nil, nil
+}
+
+// diffAPIKeyQueryParametersSuppress suppresses the diff of the query parameters which differ only in the encoding or the order.
+func diffAPIKeyQueryParametersSuppress(k, old, new string, d *schema.ResourceData) bool {
+	normalizedOld, err := normalizeAPIKeyQueryParameters(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := normalizeAPIKeyQueryParameters(new)
+	if err != nil {
+		return false
+	}
+	return normalizedOld == normalizedNew
+}
+
+// broadAPIKeyACLs is the list of ACLs which can destroy or modify indices.
+var broadAPIKeyACLs = []string{"editSettings", "deleteIndex"}
+
+// warnOverlyPermissiveAPIKey is purely advisory, it never blocks the plan.
+func warnOverlyPermissiveAPIKey(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("acl") || !d.NewValueKnown("indexes") {
+		return nil
+	}
+
+	acl := castStringSet(d.Get("acl"))
+	indexes := castStringSet(d.Get("indexes"))
+	if broadACLs := overlyPermissiveACLs(acl, indexes); len(broadACLs) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("api key has broad ACLs (%s) on all indices. Consider restricting the target indices with `indexes` to follow least privilege.", strings.Join(broadACLs, ", ")))
+	}
+	return nil
+}
+
+// validateExpiresAtInFuture rejects `expires_at` in the past, which would create an already expired key.
+// It's checked only when `expires_at` is set or changed, so that keys which have expired since don't block the plan.
+func validateExpiresAtInFuture(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("expires_at") || (d.Id() != "" && !d.HasChange("expires_at")) {
+		return nil
+	}
+	expiresAtRFC3339 := d.Get("expires_at").(string)
+	if expiresAtRFC3339 == "" {
+		return nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, expiresAtRFC3339)
+	if err != nil {
+		// the format is validated by the schema.
+		return nil
+	}
+	if !expiresAt.After(time.Now()) {
+		return fmt.Errorf("`expires_at` (%s) must be in the future, otherwise the key expires as soon as it's created", expiresAtRFC3339)
+	}
+	return nil
+}
+
+// validateACLNotEmpty returns an error if `acl` is empty since the key without any permission can't be used for anything.
+func validateACLNotEmpty(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("acl") {
+		return nil
+	}
+	if len(castStringSet(d.Get("acl"))) == 0 {
+		return errors.New("`acl` must contain at least one permission, otherwise the key can't be used for anything")
+	}
+	return nil
+}
+
+// overlyPermissiveACLs returns the broad ACLs which are granted for all indices.
+func overlyPermissiveACLs(acl []string, indexes []string) []string {
+	for _, index := range indexes {
+		if index != "*" {
+			return nil
+		}
+	}
+
+	var broadACLs []string
+	for _, a := range acl {
+		for _, broadACL := range broadAPIKeyACLs {
+			if a == broadACL {
+				broadACLs = append(broadACLs, a)
+			}
+		}
 	}
+	return broadACLs
 }
@@ -1,14 +1,25 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
 )
 
 func TestAccResourceAPIKey(t *testing.T) {
@@ -49,9 +60,8 @@ func TestAccResourceAPIKey(t *testing.T) {
 				ImportStateIdFunc: func(state *terraform.State) (string, error) {
 					return state.Modules[0].Resources[resourceName].Primary.Attributes["key"], nil
 				},
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"expires_at"},
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 		},
 		CheckDestroy: testAccCheckApiKeyDestroy,
@@ -96,3 +106,479 @@ func testAccCheckApiKeyDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func Test_overlyPermissiveACLs(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		acl     []string
+		indexes []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "broad ACLs without indexes restriction",
+			args: args{acl: []string{"search", "editSettings", "deleteIndex"}},
+			want: []string{"editSettings", "deleteIndex"},
+		},
+		{
+			name: "broad ACLs on wildcard index",
+			args: args{acl: []string{"editSettings"}, indexes: []string{"*"}},
+			want: []string{"editSettings"},
+		},
+		{
+			name: "broad ACLs with indexes restriction",
+			args: args{acl: []string{"editSettings", "deleteIndex"}, indexes: []string{"dev_*"}},
+			want: nil,
+		},
+		{
+			name: "no broad ACLs",
+			args: args{acl: []string{"search", "browse"}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlyPermissiveACLs(tt.args.acl, tt.args.indexes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("overlyPermissiveACLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceAPIKey_warnOverlyPermissiveAPIKey(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{"acl": []interface{}{"editSettings", "deleteIndex"}}
+	assertDiffWarns(t, resourceAPIKey(), raw, "api key has broad ACLs (deleteIndex, editSettings) on all indices", true)
+}
+
+func TestResourceAPIKey_validateACLNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{"acl": []interface{}{}}
+	_, err := testResourceDiff(resourceAPIKey(), raw)
+	if err == nil || !regexp.MustCompile("`acl` must contain at least one permission").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want empty acl error", err)
+	}
+}
+
+func TestResourceAPIKey_validateExpiresAtInFuture(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		expiresAt string
+		wantErr   bool
+	}{
+		{
+			name:      "future",
+			expiresAt: time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+			wantErr:   false,
+		},
+		{
+			name:      "past",
+			expiresAt: "2020-01-01T00:00:00Z",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{"acl": []interface{}{"search"}, "expires_at": tt.expiresAt}
+			_, err := testResourceDiff(resourceAPIKey(), raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !regexp.MustCompile("must be in the future").MatchString(err.Error()) {
+				t.Errorf("Diff() error = %v, want past expires_at error", err)
+			}
+		})
+	}
+}
+
+func TestResourceAPIKey_refreshAPIKeyStateDoesNotLogKey(t *testing.T) {
+	t.Parallel()
+
+	const key = "secret-api-key"
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Key does not exist","status":404}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{})
+	// the id is the created_at timestamp of the key, not the key itself.
+	d.SetId("1893456000")
+	if err := d.Set("key", key); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	if err := refreshAPIKeyState(ctx, d, apiClient); err != nil {
+		t.Fatalf("refreshAPIKeyState() error = %v", err)
+	}
+	if want := fmt.Sprintf("api key (%s) not found", algoliautil.MaskAPIKey(key)); !strings.Contains(logs.String(), want) {
+		t.Errorf("expected %q in logs, got %q", want, logs.String())
+	}
+	if strings.Contains(logs.String(), key) {
+		t.Errorf("api key is leaked in logs: %q", logs.String())
+	}
+}
+
+// fakeSearchClient is a searchClient which serves API keys from memory.
+type fakeSearchClient struct {
+	searchClient
+	keys map[string]search.Key
+}
+
+func (c *fakeSearchClient) GetAPIKey(keyID string, opts ...interface{}) (search.Key, error) {
+	key, ok := c.keys[keyID]
+	if !ok {
+		return search.Key{}, errs.AlgoliaErr{Message: "Key does not exist", Status: http.StatusNotFound}
+	}
+	return key, nil
+}
+
+func TestResourceAPIKey_refreshAPIKeyStateWithFakeClient(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{
+		"test-key": {
+			Value:       "test-key",
+			ACL:         []string{"search"},
+			Description: "test",
+			Indexes:     []string{"dev_*"},
+			CreatedAt:   createdAt,
+		},
+	}}}
+
+	d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{})
+	if err := d.Set("key", "test-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshAPIKeyState() error = %v", err)
+	}
+
+	if got, want := d.Id(), strconv.FormatInt(createdAt.Unix(), 10); got != want {
+		t.Errorf("id = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("acl")), []string{"search"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("acl = %v, want %v", got, want)
+	}
+	if got, want := castStringSet(d.Get("indexes")), []string{"dev_*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("indexes = %v, want %v", got, want)
+	}
+
+	// the key is removed from the state when it's deleted out of band.
+	delete(apiClient.searchClient.(*fakeSearchClient).keys, "test-key")
+	if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshAPIKeyState() error = %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %v, want empty", d.Id())
+	}
+}
+
+func TestResourceAPIKey_queryParametersRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		queryParameters string
+	}{
+		{
+			name:            "filters and hits per page",
+			queryParameters: "hitsPerPage=10&filters=brand%3Aapple",
+		},
+		{
+			name:            "empty",
+			queryParameters: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{
+				"acl":              []interface{}{"search"},
+				"query_parameters": tt.queryParameters,
+			})
+			key := mapToAPIKey(d)
+			key.Value = "test-key"
+			apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{"test-key": key}}}
+
+			if err := d.Set("key", "test-key"); err != nil {
+				t.Fatal(err)
+			}
+			if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+				t.Fatalf("refreshAPIKeyState() error = %v", err)
+			}
+			got := d.Get("query_parameters").(string)
+			if !diffAPIKeyQueryParametersSuppress("query_parameters", got, tt.queryParameters, d) {
+				t.Errorf("query_parameters = %q, want equivalent to %q", got, tt.queryParameters)
+			}
+			if tt.queryParameters == "" && got != "" {
+				t.Errorf("query_parameters = %q, want empty", got)
+			}
+		})
+	}
+}
+
+func TestResourceAPIKey_validateAPIKeyQueryParameters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		queryParameters string
+		wantErr         string
+	}{
+		{
+			name:            "supported parameters",
+			queryParameters: "filters=brand%3Aapple&hitsPerPage=10",
+		},
+		{
+			name:            "unsupported parameter",
+			queryParameters: "filters=brand%3Aapple&unknownParam=1",
+			wantErr:         "unsupported or empty query parameters: unknownParam",
+		},
+		{
+			name:            "invalid encoding",
+			queryParameters: "filters=%zz",
+			wantErr:         "must be URL-encoded query parameters",
+		},
+		{
+			name:            "restrictSources",
+			queryParameters: "restrictSources=192.168.1.0%2F24",
+			wantErr:         "use `restrict_sources` instead",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, errs := validateAPIKeyQueryParameters(tt.queryParameters, "query_parameters")
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateAPIKeyQueryParameters() errors = %v, want nil", errs)
+				}
+				return
+			}
+			if len(errs) == 0 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateAPIKeyQueryParameters() errors = %v, want %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_flattenAPIKeyExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		validity   time.Duration
+		configured string
+		want       string
+	}{
+		{
+			name:     "never expires",
+			validity: 0,
+			want:     "",
+		},
+		{
+			name:     "imported",
+			validity: 24*time.Hour - 2*time.Second,
+			want:     "2030-01-02T00:00:00Z",
+		},
+		{
+			name:       "configured within tolerance",
+			validity:   24*time.Hour - 2*time.Second,
+			configured: "2030-01-02T09:00:00+09:00",
+			want:       "2030-01-02T09:00:00+09:00",
+		},
+		{
+			name:       "changed out of band",
+			validity:   48 * time.Hour,
+			configured: "2030-01-02T00:00:00Z",
+			want:       "2030-01-03T00:00:00Z",
+		},
+		{
+			name:       "expiry removed out of band",
+			validity:   0,
+			configured: "2030-01-02T00:00:00Z",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := flattenAPIKeyExpiresAt(tt.validity, tt.configured, now); got != tt.want {
+				t.Errorf("flattenAPIKeyExpiresAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceAPIKey_importExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).Round(time.Minute).UTC()
+	apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{
+		"test-key": {
+			Value:     "test-key",
+			ACL:       []string{"search"},
+			Validity:  time.Until(expiresAt),
+			CreatedAt: time.Now(),
+		},
+	}}}
+
+	d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{})
+	d.SetId("test-key")
+	ds, err := resourceAPIKeyStateContext(context.Background(), d, apiClient)
+	if err != nil {
+		t.Fatalf("resourceAPIKeyStateContext() error = %v", err)
+	}
+	if got, want := ds[0].Get("expires_at").(string), expiresAt.Format(time.RFC3339); got != want {
+		t.Errorf("expires_at = %v, want %v", got, want)
+	}
+}
+
+// expiringKeySearchClient returns the key with the remaining validity at the time of the request, like Algolia does.
+type expiringKeySearchClient struct {
+	searchClient
+	key       search.Key
+	expiresAt time.Time
+}
+
+func (c *expiringKeySearchClient) GetAPIKey(keyID string, opts ...interface{}) (search.Key, error) {
+	key := c.key
+	key.Validity = time.Until(c.expiresAt).Truncate(time.Second)
+	return key, nil
+}
+
+func TestResourceAPIKey_refreshExpiresAtWithoutDrift(t *testing.T) {
+	t.Parallel()
+
+	// The expiry in the middle of a minute is the worst case of rounding the validity to the minute.
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Minute).Add(30 * time.Second)
+	tests := []struct {
+		name       string
+		configured string
+	}{
+		{
+			name: "imported",
+		},
+		{
+			name:       "configured",
+			configured: expiresAt.Format(time.RFC3339),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := &apiClient{searchClient: &expiringKeySearchClient{
+				key:       search.Key{Value: "test-key", ACL: []string{"search"}, CreatedAt: time.Now()},
+				expiresAt: expiresAt,
+			}}
+			d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{
+				"acl":        []interface{}{"search"},
+				"expires_at": tt.configured,
+			})
+			if err := d.Set("key", "test-key"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+				t.Fatalf("refreshAPIKeyState() error = %v", err)
+			}
+			first := d.Get("expires_at").(string)
+			if tt.configured != "" && first != tt.configured {
+				t.Errorf("expires_at = %v, want %v", first, tt.configured)
+			}
+
+			// The remaining validity has decreased by the next refresh.
+			time.Sleep(1100 * time.Millisecond)
+			if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+				t.Fatalf("refreshAPIKeyState() error = %v", err)
+			}
+			if got := d.Get("expires_at").(string); got != first {
+				t.Errorf("expires_at = %v after the second refresh, want %v", got, first)
+			}
+		})
+	}
+}
+
+func TestResourceAPIKey_restrictSourcesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceAPIKey().Schema, map[string]interface{}{
+		"acl":              []interface{}{"search"},
+		"query_parameters": "filters=brand%3Aapple",
+		"restrict_sources": "192.168.1.0/24",
+	})
+	key := mapToAPIKey(d)
+	key.Value = "test-key"
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "restrictSources=192.168.1.0%2F24") {
+		t.Errorf("key = %s, want restrictSources in queryParameters", b)
+	}
+
+	apiClient := &apiClient{searchClient: &fakeSearchClient{keys: map[string]search.Key{"test-key": key}}}
+	if err := d.Set("key", "test-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := refreshAPIKeyState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshAPIKeyState() error = %v", err)
+	}
+	if got := d.Get("restrict_sources").(string); got != "192.168.1.0/24" {
+		t.Errorf("restrict_sources = %v, want 192.168.1.0/24", got)
+	}
+	// restrictSources is read only as restrict_sources, not to cause a diff of query_parameters.
+	if got := d.Get("query_parameters").(string); got != "filters=brand%3Aapple" {
+		t.Errorf("query_parameters = %v, want filters=brand%%3Aapple", got)
+	}
+}
+
+func TestResourceAPIKey_invalidRestrictSources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		restrictSources string
+	}{
+		{
+			name:            "IP address without prefix length",
+			restrictSources: "192.168.1.1",
+		},
+		{
+			name:            "IPv6 network",
+			restrictSources: "2001:db8::/32",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{"acl": []interface{}{"search"}, "restrict_sources": tt.restrictSources}
+			if diags := resourceAPIKey().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+				t.Errorf("Validate() error = nil, want invalid IPv4 network error")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDictionaryCompounds() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDictionaryEntryCreate(search.Compounds),
+		ReadContext:   resourceDictionaryEntryRead(search.Compounds),
+		UpdateContext: resourceDictionaryEntryUpdate(search.Compounds),
+		DeleteContext: resourceDictionaryEntryDelete(search.Compounds),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDictionaryEntryStateContext(search.Compounds),
+		},
+		Description: "A configuration for a custom entry of the compounds dictionary. To get more information about dictionaries, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/optimize-search-results/handling-natural-languages-nlp/how-to/customize-dictionaries/).",
+		// https://www.algolia.com/doc/api-reference/api-methods/save-dictionary-entries/
+		Schema: map[string]*schema.Schema{
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the entry. Defaults to `{language}-{word}`.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Language ISO code supported by the dictionary (e.g., `de` for German).",
+			},
+			"word": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The compound word.",
+			},
+			"decomposition": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+				Description: "List of the words the compound word is decomposed into (e.g., `[\"kopf\", \"schmerz\", \"tablette\"]` for `kopfschmerztablette`).",
+			},
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDictionaryCompounds(t *testing.T) {
+	name := randResourceID(40)
+	resourceName := fmt.Sprintf("algolia_dictionary_compounds.%s", name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDictionaryCompounds(name, `["kopf", "schmerz"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_id", fmt.Sprintf("de-%s", name)),
+					resource.TestCheckResourceAttr(resourceName, "language", "de"),
+					resource.TestCheckResourceAttr(resourceName, "word", name),
+					testCheckResourceListAttr(resourceName, "decomposition", []string{"kopf", "schmerz"}),
+				),
+			},
+			{
+				Config: testAccResourceDictionaryCompounds(name, `["kopf", "schmerz", "tablette"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceListAttr(resourceName, "decomposition", []string{"kopf", "schmerz", "tablette"}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("de-%s", name),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckDictionaryEntryDestroy("algolia_dictionary_compounds", search.Compounds),
+	})
+}
+
+func testAccResourceDictionaryCompounds(name string, decomposition string) string {
+	return `
+resource "algolia_dictionary_compounds" "` + name + `" {
+  language      = "de"
+  word          = "` + name + `"
+  decomposition = ` + decomposition + `
+}
+`
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDictionaryPlurals() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDictionaryEntryCreate(search.Plurals),
+		ReadContext:   resourceDictionaryEntryRead(search.Plurals),
+		UpdateContext: resourceDictionaryEntryUpdate(search.Plurals),
+		DeleteContext: resourceDictionaryEntryDelete(search.Plurals),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDictionaryEntryStateContext(search.Plurals),
+		},
+		Description: "A configuration for a custom entry of the plurals dictionary. To get more information about dictionaries, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/optimize-search-results/handling-natural-languages-nlp/how-to/customize-dictionaries/).",
+		// https://www.algolia.com/doc/api-reference/api-methods/save-dictionary-entries/
+		Schema: map[string]*schema.Schema{
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the entry. Defaults to `{language}-{the first word of words}`.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Language ISO code supported by the dictionary (e.g., `en` for English).",
+			},
+			"words": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+				MinItems:    2,
+				Description: "List of the word's inflections (e.g., `[\"mouse\", \"mice\"]`).",
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDictionaryPlurals(t *testing.T) {
+	name := randResourceID(40)
+	resourceName := fmt.Sprintf("algolia_dictionary_plurals.%s", name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDictionaryPlurals(name, `["`+name+`", "`+name+`s"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_id", fmt.Sprintf("en-%s", name)),
+					resource.TestCheckResourceAttr(resourceName, "language", "en"),
+					testCheckResourceListAttr(resourceName, "words", []string{name, name + "s"}),
+				),
+			},
+			{
+				Config: testAccResourceDictionaryPlurals(name, `["`+name+`", "`+name+`s", "`+name+`es"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_id", fmt.Sprintf("en-%s", name)),
+					testCheckResourceListAttr(resourceName, "words", []string{name, name + "s", name + "es"}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("en-%s", name),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckDictionaryEntryDestroy("algolia_dictionary_plurals", search.Plurals),
+	})
+}
+
+func testAccResourceDictionaryPlurals(name string, words string) string {
+	return `
+resource "algolia_dictionary_plurals" "` + name + `" {
+  language = "en"
+  words    = ` + words + `
+}
+`
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDictionaryStopwords() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDictionaryEntryCreate(search.Stopwords),
+		ReadContext:   resourceDictionaryEntryRead(search.Stopwords),
+		UpdateContext: resourceDictionaryEntryUpdate(search.Stopwords),
+		DeleteContext: resourceDictionaryEntryDelete(search.Stopwords),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDictionaryEntryStateContext(search.Stopwords),
+		},
+		Description: "A configuration for a custom entry of the stop words dictionary. To get more information about dictionaries, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/optimize-search-results/handling-natural-languages-nlp/how-to/customize-dictionaries/).",
+		// https://www.algolia.com/doc/api-reference/api-methods/save-dictionary-entries/
+		Schema: map[string]*schema.Schema{
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the entry. Defaults to `{language}-{word}`.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Language ISO code supported by the dictionary (e.g., `en` for English).",
+			},
+			"word": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The stop word.",
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enabled",
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+				Description:  "The state of the entry. Possible values are `enabled` and `disabled`.",
+			},
+		},
+	}
+}
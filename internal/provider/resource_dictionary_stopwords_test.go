@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccResourceDictionaryStopwords(t *testing.T) {
+	name := randResourceID(40)
+	resourceName := fmt.Sprintf("algolia_dictionary_stopwords.%s", name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDictionaryStopwords(name, "enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_id", fmt.Sprintf("en-%s", name)),
+					resource.TestCheckResourceAttr(resourceName, "language", "en"),
+					resource.TestCheckResourceAttr(resourceName, "word", name),
+					resource.TestCheckResourceAttr(resourceName, "state", "enabled"),
+				),
+			},
+			{
+				Config: testAccResourceDictionaryStopwords(name, "disabled"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "object_id", fmt.Sprintf("en-%s", name)),
+					resource.TestCheckResourceAttr(resourceName, "state", "disabled"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("en-%s", name),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckDictionaryEntryDestroy("algolia_dictionary_stopwords", search.Stopwords),
+	})
+}
+
+func TestResourceDictionaryStopwords_createAndRead(t *testing.T) {
+	t.Parallel()
+
+	var savedEntries []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/dictionaries/stopwords/batch":
+			savedEntries, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/dictionaries/stopwords/search":
+			_, _ = w.Write([]byte(`{"hits":[
+				{"objectID":"en-the","language":"en","word":"the","state":"enabled","type":"standard"},
+				{"objectID":"en-foo","language":"en","word":"foo","state":"disabled","type":"custom"}
+			],"nbHits":2,"page":0,"nbPages":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceDictionaryStopwords().Schema, map[string]interface{}{
+		"language": "en",
+		"word":     "foo",
+		"state":    "disabled",
+	})
+
+	if diags := resourceDictionaryEntryCreate(search.Stopwords)(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceDictionaryEntryCreate() error = %v", diags)
+	}
+
+	want := `{"requests":[{"action":"addEntry","body":{"objectID":"en-foo","language":"en","word":"foo","state":"disabled"}}],"clearExistingDictionaryEntries":false}`
+	if ok, _ := jsonBytesEqual(savedEntries, []byte(want)); !ok {
+		t.Errorf("saved entries = %s, want %s", savedEntries, want)
+	}
+	if got := d.Id(); got != "en-foo" {
+		t.Errorf("id = %v, want en-foo", got)
+	}
+	if got := d.Get("state").(string); got != "disabled" {
+		t.Errorf("state = %v, want disabled", got)
+	}
+}
+
+func TestResourceDictionaryStopwords_importNotFound(t *testing.T) {
+	t.Parallel()
+
+	var searchedPages []int
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/dictionaries/stopwords/search":
+			var body struct {
+				Query string `json:"query"`
+				Page  int    `json:"page"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			searchedPages = append(searchedPages, body.Page)
+			_, _ = fmt.Fprintf(w, `{"hits":[{"objectID":"en-the-%d","language":"en","word":"the"}],"nbHits":2,"page":%d,"nbPages":2}`, body.Page, body.Page)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceDictionaryStopwords().Schema, map[string]interface{}{})
+	d.SetId("en-foo")
+
+	_, err := resourceDictionaryEntryStateContext(search.Stopwords)(context.Background(), d, apiClient)
+	if err == nil || !regexp.MustCompile(`entry \(en-foo\) is not found in the stopwords dictionary`).MatchString(err.Error()) {
+		t.Errorf("resourceDictionaryEntryStateContext() error = %v, want not found error", err)
+	}
+	if len(searchedPages) != 2 {
+		t.Errorf("searched pages = %v, want all the 2 pages", searchedPages)
+	}
+}
+
+func testAccResourceDictionaryStopwords(name string, state string) string {
+	return `
+resource "algolia_dictionary_stopwords" "` + name + `" {
+  language = "en"
+  word     = "` + name + `"
+  state    = "` + state + `"
+}
+`
+}
@@ -2,14 +2,24 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/personalization"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
@@ -24,7 +34,30 @@ func resourceIndex() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceIndexStateContext,
 		},
-		Description: "A configuration for an index.",
+		CustomizeDiff: customdiff.All(
+			validateVirtualIndexHasPrimary,
+			validateSearchableAttributesNotDuplicated,
+			validateRankingNotDuplicated,
+			validateLanguageSettingsNotConflicting,
+			warnUnretrievableAttributesToRetrieve,
+			warnEmptySearchableAttributes,
+			warnFacetFiltersWithoutAttributesForFaceting,
+			warnRuleFacetsNotInAttributesForFaceting,
+			warnInconsistentFacetValuesSort,
+			warnUnexpectedSortFacetValuesBy,
+			warnPersonalizationWithoutStrategy,
+			warnLanguageFeaturesWithoutQueryLanguages,
+			warnDecompoundQueryWithoutDecompoundingLanguages,
+			warnReplicaWithoutSortCriteria,
+			warnPaginationLimitedToLowered,
+			validateMinWordSizesForTypos,
+			warnTypoSettingsWithoutTypoTolerance,
+			warnSuspiciousHighlightTags,
+			warnSnippetWithoutHighlight,
+			warnAllOptionalWithOptionalWords,
+			warnPrimaryIndexNameChange,
+		),
+		Description: "A configuration for an index. The plan warns about the settings that are valid but likely unintended (e.g. `facet_filters` on an attribute missing in `attributes_for_faceting`). Except for the ones on a single attribute, these warnings don't show up in the plan output and are written only to the provider logs, which are shown with `TF_LOG=WARN`.",
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(1 * time.Hour),
 		},
@@ -40,13 +73,24 @@ func resourceIndex() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "The name of the existing primary index name. This field is used to create a replica index.",
+				Description: "The name of the existing primary index name. This field is used to create a replica index. Changing it deletes and recreates the index, so all records of the index are lost. Reference the primary index resource (e.g. `algolia_index.primary.name`) so that the primary index settings are applied before the replica ones.",
+			},
+			"replicas": {
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Set:           schema.HashString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"primary_index_name"},
+				Description: `The replicas of the index. A virtual replica is declared as ` + "`virtual(<name>)`" + `. The replicas are created by Algolia with the default settings, and the ones removed from this field are detached and become regular indices.
+When this field isn't configured, the current replicas are only read, e.g. the ones declared with ` + "`primary_index_name`" + ` or ` + "`algolia_virtual_index`" + `. Don't configure this field together with them, otherwise they overwrite one another.
+When the index is destroyed, all its replicas are detached by Algolia and become regular indices.`,
 			},
 			"virtual": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "**Deprecated:** Use `algolia_virtual_index` resource instead. Whether the index is virtual index. If true, applying the params listed in the [doc](https://www.algolia.com/doc/guides/managing-results/refine-results/sorting/in-depth/replicas/#unsupported-parameters) will be ignored.",
+				Description: "**Deprecated:** Use `algolia_virtual_index` resource instead. Whether the index is virtual index. If true, applying the params listed in the [doc](https://www.algolia.com/doc/guides/managing-results/refine-results/sorting/in-depth/replicas/#unsupported-parameters) will be ignored. `primary_index_name` is required when it's true.",
 				Deprecated:  "Use `algolia_virtual_index` resource instead",
 			},
 			"attributes_config": {
@@ -65,10 +109,10 @@ func resourceIndex() *schema.Resource {
 						},
 						"attributes_for_faceting": {
 							Type:        schema.TypeSet,
-							Elem:        &schema.Schema{Type: schema.TypeString},
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateFacetAttribute},
 							Set:         schema.HashString,
 							Optional:    true,
-							Description: "The complete list of attributes that will be used for faceting.",
+							Description: "The complete list of attributes that will be used for faceting. Attributes can be wrapped with the `searchable()`, `filterOnly()` and `afterDistinct()` modifiers, which must be written in the canonical form (e.g. `filterOnly(price)`, not `filteronly( price )`).",
 						},
 						"unretrievable_attributes": {
 							Type:        schema.TypeSet,
@@ -120,6 +164,23 @@ func resourceIndex() *schema.Resource {
 							ValidateFunc: validation.IntBetween(0, 100),
 							Description:  "Relevancy threshold below which less relevant results aren’t included in the results",
 						},
+						"re_ranking_apply_filter": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:     schema.TypeList,
+								MinItems: 1,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							Description: `Filters to restrict [Dynamic Re-Ranking](https://www.algolia.com/doc/guides/algolia-ai/re-ranking/) to the matching records. The filters in a nested list are combined with OR, and the nested lists are combined with AND, like ` + "`facetFilters`" + `.
+` + "```" + `
+[
+  ["category:Book", "category:Movie"],
+  ["brand:Algolia"]
+]
+` + "```" + `
+It's ignored for a virtual index.`,
+						},
 					},
 				},
 			},
@@ -142,8 +203,77 @@ func resourceIndex() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "count",
-							ValidateFunc: validation.StringInSlice([]string{"alpha", "count"}, false),
-							Description:  "Parameter to controls how the facet values are sorted within each faceted attribute.",
+							ValidateFunc: validation.StringInSlice(sortFacetValuesByValues, false),
+							Description:  "Parameter to controls how the facet values are sorted within each faceted attribute. Possible values are `alpha` and `count`.",
+						},
+					},
+				},
+			},
+			"rendering_config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The configuration for how the search results are rendered in the UI.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"facet_ordering": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The ordering of facets and their values.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"facets": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										MaxItems:    1,
+										Description: "The ordering of facets.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"order": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Required:    true,
+													Description: "List of facets in the order they should be displayed. Facets not listed are displayed after them.",
+												},
+											},
+										},
+									},
+									"values": {
+										Type:        schema.TypeSet,
+										Optional:    true,
+										Description: "The ordering of facet values, per facet.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"facet": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "Name of the facet.",
+												},
+												"order": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Optional:    true,
+													Description: "List of facet values in the order they should be displayed.",
+												},
+												"sort_remaining_by": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"alpha", "count", "hidden"}, false),
+													Description:  "How the facet values not listed in `order` are sorted. Possible values are `alpha`, `count` and `hidden`.",
+												},
+												"hide": {
+													Type:        schema.TypeList,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Optional:    true,
+													Description: "List of facet values to hide.",
+												},
+											},
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -162,7 +292,7 @@ func resourceIndex() *schema.Resource {
 							Set:         schema.HashString,
 							Optional:    true,
 							Computed:    true,
-							Description: "List of attributes to highlight.",
+							Description: "List of attributes to highlight. For a virtual index, it's inherited from the primary index when it's not set.",
 						},
 						"attributes_to_snippet": {
 							Type:        schema.TypeSet,
@@ -173,16 +303,18 @@ func resourceIndex() *schema.Resource {
 							Description: "List of attributes to snippet, with an optional maximum number of words to snippet.",
 						},
 						"highlight_pre_tag": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "<em>",
-							Description: "The HTML string to insert before the highlighted parts in all highlight and snippet results.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "<em>",
+							ValidateDiagFunc: validateHighlightTag,
+							Description:      "The HTML string to insert before the highlighted parts in all highlight and snippet results.",
 						},
 						"highlight_post_tag": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "</em>",
-							Description: "The HTML string to insert after the highlighted parts in all highlight and snippet results.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "</em>",
+							ValidateDiagFunc: validateHighlightTag,
+							Description:      "The HTML string to insert after the highlighted parts in all highlight and snippet results.",
 						},
 						"snippet_ellipsis_text": {
 							Type:        schema.TypeString,
@@ -248,8 +380,8 @@ func resourceIndex() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "true",
-							ValidateFunc: validation.StringInSlice([]string{"true", "false", "min", "strict"}, false),
-							Description:  "Whether typo tolerance is enabled and how it is applied",
+							ValidateFunc: validateTypoTolerance,
+							Description:  "Whether typo tolerance is enabled and how it is applied. Possible values are `true`, `false`, `min` and `strict`. To configure the word sizes to accept typos, use `min_word_size_for_1_typo` and `min_word_size_for_2_typos` instead.",
 						},
 						"allow_typos_on_numeric_tokens": {
 							Type:        schema.TypeBool,
@@ -287,18 +419,16 @@ func resourceIndex() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"ignore_plurals": {
-							Type:          schema.TypeBool,
-							Optional:      true,
-							Default:       false,
-							ConflictsWith: []string{"languages_config.0.ignore_plurals_for"},
-							Description:   "Whether to treat singular, plurals, and other forms of declensions as matching terms.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to treat singular, plurals, and other forms of declensions as matching terms. It can't be true when `ignore_plurals_for` is set.",
 						},
 						"ignore_plurals_for": {
-							Type:          schema.TypeSet,
-							Elem:          &schema.Schema{Type: schema.TypeString},
-							Set:           schema.HashString,
-							Optional:      true,
-							ConflictsWith: []string{"languages_config.0.ignore_plurals"},
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+							Optional: true,
 							Description: `Whether to treat singular, plurals, and other forms of declensions as matching terms in target languages.
 List of supported languages are listed on http://nhttps//www.algolia.com/doc/api-reference/api-parameters/ignorePlurals/#usage-notes`,
 						},
@@ -311,19 +441,17 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Description: "List of attributes to apply transliteration",
 						},
 						"remove_stop_words": {
-							Type:          schema.TypeBool,
-							Optional:      true,
-							Default:       false,
-							ConflictsWith: []string{"languages_config.0.remove_stop_words_for"},
-							Description:   "Whether to removes stop (common) words from the query before executing it.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to removes stop (common) words from the query before executing it. It can't be true when `remove_stop_words_for` is set.",
 						},
 						"remove_stop_words_for": {
-							Type:          schema.TypeSet,
-							Elem:          &schema.Schema{Type: schema.TypeString},
-							Set:           schema.HashString,
-							Optional:      true,
-							ConflictsWith: []string{"languages_config.0.remove_stop_words"},
-							Description:   "List of languages to removes stop (common) words from the query before executing it.",
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Optional:    true,
+							Description: "List of languages to removes stop (common) words from the query before executing it.",
 						},
 						"camel_case_attributes": {
 							Type:        schema.TypeSet,
@@ -361,7 +489,27 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Type:        schema.TypeMap,
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Optional:    true,
-							Description: "Custom normalization which overrides the engine’s default normalization",
+							Description: "Custom normalization which overrides the engine’s default normalization. It's applied to all the languages as the `default` language bucket. Use `custom_normalization_by_language` to set it per language.",
+						},
+						"custom_normalization_by_language": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Custom normalization which overrides the engine’s default normalization, per language bucket. The `default` bucket applies to all the languages.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"language": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Language bucket of the normalization, e.g. `default` or `de`.",
+									},
+									"normalization": {
+										Type:        schema.TypeMap,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Required:    true,
+										Description: "Map of the characters to their normalized form.",
+									},
+								},
+							},
 						},
 						"query_languages": {
 							Type:        schema.TypeSet,
@@ -381,7 +529,7 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Type:        schema.TypeBool,
 							Optional:    true,
 							Default:     true,
-							Description: "Whether to split compound words into their composing atoms in the query.",
+							Description: "Whether to split compound words into their composing atoms in the query. It only applies to the query languages supporting decompounding: `da`, `de`, `fi`, `nl`, `no` and `sv`.",
 						},
 					},
 				},
@@ -398,6 +546,13 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 				Default:     false,
 				Description: "Whether to enable the Personalization feature.",
 			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"neuralSearch", "keywordSearch"}, false),
+				Description:  "Search mode the index uses to query for results. Possible values are `neuralSearch` and `keywordSearch`. Defaults to `keywordSearch` on Algolia's side.",
+			},
 			"query_strategy_config": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -407,18 +562,18 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"query_type": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      "prefixLast",
-							ValidateFunc: validation.StringInSlice([]string{"prefixLast", "prefixAll", "prefixNone"}, false),
-							Description:  "Query type to control if and how query words are interpreted as prefixes.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "prefixLast",
+							ValidateDiagFunc: validateQueryType,
+							Description:      "Query type to control if and how query words are interpreted as prefixes. Note that `prefixAll` can significantly slow down the search and degrade the relevance, see the [Official Documentation](https://www.algolia.com/doc/api-reference/api-parameters/queryType/).",
 						},
 						"remove_words_if_no_results": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "none",
 							ValidateFunc: validation.StringInSlice([]string{"none", "lastWords", "firstWords", "allOptional"}, false),
-							Description:  "Strategy to remove words from the query when it doesn’t match any hits.",
+							Description:  "Strategy to remove words from the query when it doesn’t match any hits. Note that `allOptional` makes all the query words optional, not only `optional_words`.",
 						},
 						"advanced_syntax": {
 							Type:        schema.TypeBool,
@@ -451,7 +606,7 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "attribute",
-							ValidateFunc: validation.StringInSlice([]string{"none", "lastWords", "firstWords", "allOptional"}, false),
+							ValidateFunc: validation.StringInSlice([]string{"attribute", "none", "word"}, false),
 							Description:  "Controls how the exact ranking criterion is computed when the query contains only one word.",
 						},
 						"alternatives_as_exact": {
@@ -493,10 +648,11 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Description: "List of numeric attributes that can be used as numerical filters.",
 						},
 						"allow_compression_of_integer_array": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Whether to enable compression of large integer arrays.",
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Default:          false,
+							ValidateDiagFunc: validateAllowCompressionOfIntegerArray,
+							Description:      "Whether to enable compression of large integer arrays. The arrays must contain only integers, and their order may change.",
 						},
 					},
 				},
@@ -569,6 +725,42 @@ This parameter is mainly intended to **limit the response size.** For example, i
 					},
 				},
 			},
+			"seed_objects_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffSeedObjectsSuppress,
+				ValidateFunc:     validation.StringIsJSON,
+				Description: `JSON array of records to push to the index on creation. It's a bootstrap convenience for demo / test environments.
+The records are saved only when the index is created, and changes to this field are **not** reconciled on update.
+Records without ` + "`objectID`" + ` are saved with an auto-generated ` + "`objectID`" + `. If saving them fails, the index is tainted and recreated on the next apply.`,
+			},
+			"forward_to_replicas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to forward the settings changes to the replicas of the index.",
+			},
+			"ignore_settings_on_replica": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(algoliaSettingNames(), false)},
+				Set:      schema.HashString,
+				Optional: true,
+				Description: `A list of settings not to forward to the replicas even if ` + "`forward_to_replicas`" + ` is true. Settings must be specified by the API parameter name (e.g. ` + "`customRanking`" + `).
+Since Algolia can only forward the whole settings request, the settings are updated by two separate requests when this field is set: one forwarded to the replicas and one only applied to this index.
+Note that the two requests are not atomic.`,
+			},
+			"wait_for_task": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: `Whether to wait for the settings update task to be published. Defaults to ` + "`wait_for_task`" + ` of the provider when not specified.
+Setting it to false speeds up applying high-churn indices, but the settings read right after the update may be stale and produce a diff on the next plan.`,
+			},
+			"detect_unmanaged_drift": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to log the settings set outside Terraform which are not managed by this resource on refresh. It's purely informational and never changes the plan.",
+			},
 			"deletion_protection": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -594,32 +786,52 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		primaryIndex := apiClient.searchClient.InitIndex(primaryIndexName)
 		primaryIndexSettings, err := primaryIndex.GetSettings(ctx)
 		if err != nil {
-			return diag.FromErr(err)
+			return diag.Errorf("failed to get settings of primary index (%s): %v", primaryIndexName, err)
 		}
 		if !algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, false) {
 			newReplicas := append(primaryIndexSettings.Replicas.Get(), indexName)
-			res, err := primaryIndex.SetSettings(search.Settings{
-				Replicas: opt.Replicas(newReplicas...),
+			err := retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+				res, err := primaryIndex.SetSettings(search.Settings{
+					Replicas: opt.Replicas(newReplicas...),
+				})
+				if err != nil {
+					return err
+				}
+				return res.Wait()
 			})
 			if err != nil {
-				return diag.FromErr(err)
-			}
-			if err := res.Wait(); err != nil {
-				return diag.FromErr(err)
+				return diag.FromErr(algoliautil.HumanizeError(err))
 			}
 		}
+	} else {
+		// Lock the primary index while applying its settings so that its replicas managed in the same run
+		// apply their settings after the primary's ones (e.g. `attributes_for_faceting`) they depend on.
+		mutexKV.Lock(ctx, algoliaIndexMutexKey(apiClient.appID, indexName))
+		defer mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, indexName))
 	}
 
 	index := apiClient.searchClient.InitIndex(indexName)
-	res, err := index.SetSettings(mapToIndexSettings(d))
+	err := retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		return setIndexSettings(index, mapToIndexSettings(d), d.Get("forward_to_replicas").(bool), castStringSet(d.Get("ignore_settings_on_replica")), shouldWaitForTask(d, apiClient))
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+	// The index exists once its settings are applied, so the ID is set before the following steps
+	// not to orphan the index when they fail. The index is marked as tainted and recreated then.
+	d.SetId(indexName)
+
+	if v, ok := d.GetOk("replicas"); ok {
+		if err := setIndexReplicas(ctx, index, castStringSet(v), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	d.SetId(indexName)
+	if v, ok := d.GetOk("seed_objects_json"); ok {
+		if err := saveSeedObjects(ctx, index, v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	return resourceIndexRead(ctx, d, m)
 }
@@ -634,12 +846,22 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, m interface{
 func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*apiClient)
 
+	// Settings of the primary index and its replicas are applied one by one, not to apply the replica's settings
+	// while the primary's ones they depend on are being updated.
+	lockedIndexName := d.Id()
+	if v, ok := d.GetOk("primary_index_name"); ok {
+		lockedIndexName = v.(string)
+	}
+	mutexKV.Lock(ctx, algoliaIndexMutexKey(apiClient.appID, lockedIndexName))
 	index := apiClient.searchClient.InitIndex(d.Id())
-	res, err := index.SetSettings(mapToIndexSettings(d))
-	if err != nil {
-		return diag.FromErr(err)
+	err := retryWrite(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
+		return setIndexSettings(index, mapToIndexSettings(d), d.Get("forward_to_replicas").(bool), castStringSet(d.Get("ignore_settings_on_replica")), shouldWaitForTask(d, apiClient))
+	})
+	if err == nil && d.HasChange("replicas") {
+		err = setIndexReplicas(ctx, index, castStringSet(d.Get("replicas")), d.Timeout(schema.TimeoutUpdate))
 	}
-	if err = res.Wait(); err != nil {
+	mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, lockedIndexName))
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -663,65 +885,163 @@ func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, m interfac
 
 		primaryIndex := apiClient.searchClient.InitIndex(primaryIndexName)
 		primaryIndexSettings, err := primaryIndex.GetSettings(ctx)
-		if err != nil {
-			return diag.FromErr(err)
+		if err != nil && !algoliautil.IsNotFoundError(err) {
+			return diag.FromErr(algoliautil.HumanizeError(err))
 		}
-		if algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, false) {
+		// The primary index may have been deleted already, then there is no replica setting to update.
+		if err == nil && algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, false) {
 			newReplicas := algoliautil.RemoveIndexFromReplicas(primaryIndexSettings.Replicas.Get(), indexName, false)
 			updateReplicasRes, err := primaryIndex.SetSettings(search.Settings{
 				Replicas: opt.Replicas(newReplicas...),
 			})
 			if err != nil {
-				return diag.FromErr(err)
+				return diag.FromErr(algoliautil.HumanizeError(err))
 			}
 			if err := updateReplicasRes.Wait(); err != nil {
-				return diag.FromErr(err)
+				return diag.FromErr(algoliautil.HumanizeError(err))
 			}
 		}
 	}
 
 	index := apiClient.searchClient.InitIndex(indexName)
+	// The replicas are left as they are, since Algolia detaches them when the index is deleted.
+	// `replicas` is always read, so it can't tell the replicas declared here from the ones declared with
+	// `primary_index_name` or `algolia_virtual_index`, and the config to tell them apart isn't given on destroy.
 	deleteIndexRes, err := index.Delete(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		// The index may have been deleted out of band.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err := deleteIndexRes.Wait(ctx); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
 }
 
 func resourceIndexStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// The fields which aren't stored in Algolia are set to the defaults, otherwise the first plan after import shows a diff for them.
+	indexSchema := resourceIndex().Schema
+	for _, key := range indexLocalFields {
+		if err := d.Set(key, indexSchema[key].Default); err != nil {
+			return nil, err
+		}
+	}
 	if err := refreshIndexState(ctx, d, m); err != nil {
 		return nil, err
 	}
 
+	if primaryIndexName := d.Get("primary_index_name").(string); primaryIndexName != "" {
+		isVirtual, err := isVirtualReplica(ctx, m.(*apiClient), primaryIndexName, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		if isVirtual {
+			tflog.Warn(ctx, fmt.Sprintf("index (%s) is a virtual replica of primary index (%s), consider importing it as `algolia_virtual_index` instead", d.Id(), primaryIndexName))
+			if err := d.Set("virtual", true); err != nil {
+				return nil, err
+			}
+			// refresh again to ignore the settings which are not supported by virtual replica.
+			if err := refreshIndexState(ctx, d, m); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
+// indexLocalFields are the fields with defaults which only exist in Terraform and are never read from Algolia.
+var indexLocalFields = []string{"forward_to_replicas", "detect_unmanaged_drift", "deletion_protection"}
+
+// isVirtualReplica returns whether the index is registered as a virtual replica (`virtual(name)`) in the primary index's replicas.
+func isVirtualReplica(ctx context.Context, apiClient *apiClient, primaryIndexName string, indexName string) (bool, error) {
+	primaryIndexSettings, err := apiClient.searchClient.InitIndex(primaryIndexName).GetSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get settings of primary index (%s): %w", primaryIndexName, err)
+	}
+	return algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, true), nil
+}
+
 func refreshIndexState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	settings, err := getIndexSettings(ctx, d, m)
+	if err != nil || settings == nil {
+		return err
+	}
+	if d.Get("detect_unmanaged_drift").(bool) {
+		unmanagedSettings, err := findUnmanagedSettings(*settings, mapToIndexSettings(d))
+		if err != nil {
+			return err
+		}
+		if len(unmanagedSettings) > 0 {
+			tflog.Info(ctx, fmt.Sprintf("index (%s) has settings which are not managed by Terraform: %s", d.Id(), strings.Join(unmanagedSettings, ", ")))
+		}
+	}
+	values := mapToIndexResourceValues(d, *settings)
+	values["replicas"] = settings.Replicas.Get()
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getIndexSettings returns the settings of the index. It returns nil and removes the index from state if it's not found.
+func getIndexSettings(ctx context.Context, d *schema.ResourceData, m interface{}) (*search.Settings, error) {
 	apiClient := m.(*apiClient)
 
 	index := apiClient.searchClient.InitIndex(d.Id())
+	// The settings are requested without any option so that the full settings are returned.
+	// `responseFields` and `attributesToRetrieve` of the index only apply to search and browse queries, never to the settings.
 	settings, err := index.GetSettings(ctx)
 	if err != nil {
 		if algoliautil.IsNotFoundError(err) {
 			tflog.Warn(ctx, fmt.Sprintf("index (%s) not found, removing from state", d.Id()))
 			d.SetId("")
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, algoliautil.HumanizeError(fmt.Errorf("failed to get settings of index (%s): %w", d.Id(), err))
 	}
-	if err := setValues(d, mapToIndexResourceValues(d, settings)); err != nil {
-		return err
+	// When the primary index is deleted, its replicas are detached and become regular indices.
+	if primaryIndexName := d.Get("primary_index_name").(string); primaryIndexName != "" && settings.Primary.Get() == "" {
+		tflog.Warn(ctx, fmt.Sprintf("index (%s) is no longer a replica of primary index (%s), the primary index may have been deleted", d.Id(), primaryIndexName))
 	}
 
-	return nil
+	return &settings, nil
+}
+
+// unmanagedSettingsExclusions are the settings which are managed by other fields or resources.
+var unmanagedSettingsExclusions = map[string]bool{"primary": true, "replicas": true}
+
+// findUnmanagedSettings returns the names of the settings which are set in the remote settings,
+// but not written by the provider.
+func findUnmanagedSettings(remote search.Settings, managed search.Settings) ([]string, error) {
+	remoteMap, err := settingsToRawMap(remote)
+	if err != nil {
+		return nil, err
+	}
+	managedMap, err := settingsToRawMap(managed)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmanagedSettings []string
+	for name := range remoteMap {
+		if _, ok := managedMap[name]; ok || unmanagedSettingsExclusions[name] {
+			continue
+		}
+		unmanagedSettings = append(unmanagedSettings, name)
+	}
+	sort.Strings(unmanagedSettings)
+	return unmanagedSettings, nil
 }
 
 func mapToIndexResourceValues(d *schema.ResourceData, settings search.Settings) map[string]interface{} {
-	isVirtualIndex := d.Get("virtual").(bool)
+	// `virtual` is missing in the resources sharing the settings, e.g. algolia_localized_indices.
+	isVirtualIndex, _ := d.Get("virtual").(bool)
 
 	return map[string]interface{}{
 		"name":               d.Id(),
@@ -733,6 +1053,7 @@ func mapToIndexResourceValues(d *schema.ResourceData, settings search.Settings)
 			"max_values_per_facet": settings.MaxValuesPerFacet.Get(),
 			"sort_facet_values_by": settings.SortFacetValuesBy.Get(),
 		}},
+		"rendering_config": marshalRenderingConfig(settings),
 		"highlight_and_snippet_config": []interface{}{map[string]interface{}{
 			"attributes_to_highlight":               settings.AttributesToHighlight.Get(),
 			"attributes_to_snippet":                 settings.AttributesToSnippet.Get(),
@@ -746,9 +1067,10 @@ func mapToIndexResourceValues(d *schema.ResourceData, settings search.Settings)
 			"pagination_limited_to": settings.PaginationLimitedTo.Get(),
 		}},
 		"typos_config":           marshalTyposConfig(settings, isVirtualIndex),
-		"languages_config":       marshalLanguageConfig(settings, isVirtualIndex),
+		"languages_config":       marshalLanguageConfig(settings, isVirtualIndex, isCustomNormalizationByLanguage(d)),
 		"enable_rules":           settings.EnableRules.Get(),
 		"enable_personalization": settings.EnablePersonalization.Get(),
+		"mode":                   getMode(settings),
 		"query_strategy_config":  marshalQueryStrategyConfig(settings, isVirtualIndex),
 		"performance_config":     marshalPerformanceConfig(settings, isVirtualIndex),
 		"advanced_config":        marshalAdvancedConfig(settings, isVirtualIndex),
@@ -775,11 +1097,40 @@ func marshalRankingConfig(settings search.Settings, isVirtualIndex bool) []inter
 	}
 	if !isVirtualIndex {
 		rankingConfig["ranking"] = settings.Ranking.Get()
+		rankingConfig["re_ranking_apply_filter"] = settings.ReRankingApplyFilter.Get()
 	}
 
 	return []interface{}{rankingConfig}
 }
 
+func marshalRenderingConfig(settings search.Settings) []interface{} {
+	content := getRenderingContent(settings)
+	if content == nil || content.FacetOrdering == nil {
+		return []interface{}{map[string]interface{}{}}
+	}
+
+	facetOrdering := map[string]interface{}{}
+	if facets := content.FacetOrdering.Facets; facets != nil {
+		facetOrdering["facets"] = []interface{}{map[string]interface{}{
+			"order": facets.Order,
+		}}
+	}
+	var values []interface{}
+	for facet, order := range content.FacetOrdering.Values {
+		values = append(values, map[string]interface{}{
+			"facet":             facet,
+			"order":             order.Order,
+			"sort_remaining_by": order.SortRemainingBy,
+			"hide":              order.Hide,
+		})
+	}
+	facetOrdering["values"] = values
+
+	return []interface{}{map[string]interface{}{
+		"facet_ordering": []interface{}{facetOrdering},
+	}}
+}
+
 func marshalTyposConfig(settings search.Settings, isVirtualIndex bool) []interface{} {
 	var typoTolerance string
 	if b, s := settings.TypoTolerance.Get(); s != "" {
@@ -803,7 +1154,13 @@ func marshalTyposConfig(settings search.Settings, isVirtualIndex bool) []interfa
 	return []interface{}{typosConfig}
 }
 
-func marshalLanguageConfig(settings search.Settings, isVirtualIndex bool) []interface{} {
+// isCustomNormalizationByLanguage returns whether the custom normalization is managed by `custom_normalization_by_language`.
+func isCustomNormalizationByLanguage(d *schema.ResourceData) bool {
+	customNormalizationByLanguage, ok := d.Get("languages_config.0.custom_normalization_by_language").(*schema.Set)
+	return ok && customNormalizationByLanguage.Len() > 0
+}
+
+func marshalLanguageConfig(settings search.Settings, isVirtualIndex bool, customNormalizationByLanguage bool) []interface{} {
 	var ignorePlurals, ignorePluralsFor interface{}
 	if ignore, languages := settings.IgnorePlurals.Get(); len(languages) > 0 {
 		ignorePluralsFor = languages
@@ -818,13 +1175,7 @@ func marshalLanguageConfig(settings search.Settings, isVirtualIndex bool) []inte
 		removeStopWords = remove
 	}
 
-	var decompoundedAttributes []interface{}
-	for language, attributes := range settings.DecompoundedAttributes.Get() {
-		decompoundedAttributes = append(decompoundedAttributes, map[string]interface{}{
-			"language":   language,
-			"attributes": attributes,
-		})
-	}
+	decompoundedAttributes := flattenDecompoundedAttributes(settings.DecompoundedAttributes.Get())
 
 	languageConfig := map[string]interface{}{
 		"ignore_plurals":              ignorePlurals,
@@ -837,7 +1188,15 @@ func marshalLanguageConfig(settings search.Settings, isVirtualIndex bool) []inte
 	}
 	if !isVirtualIndex {
 		languageConfig["camel_case_attributes"] = settings.CamelCaseAttributes.Get()
-		languageConfig["custom_normalization"] = settings.CustomNormalization.Get()["default"]
+		customNormalization := settings.CustomNormalization.Get()
+		// The language buckets other than `default` can only be represented by `custom_normalization_by_language`.
+		if _, hasDefaultOnly := customNormalization["default"]; customNormalizationByLanguage || len(customNormalization) > 1 || (len(customNormalization) == 1 && !hasDefaultOnly) {
+			languageConfig["custom_normalization"] = map[string]string{}
+			languageConfig["custom_normalization_by_language"] = flattenCustomNormalization(customNormalization)
+		} else {
+			languageConfig["custom_normalization"] = customNormalization["default"]
+			languageConfig["custom_normalization_by_language"] = []interface{}{}
+		}
 		languageConfig["decompounded_attributes"] = decompoundedAttributes
 		languageConfig["keep_diacritics_on_characters"] = settings.KeepDiacriticsOnCharacters.Get()
 		languageConfig["index_languages"] = settings.IndexLanguages.Get()
@@ -846,6 +1205,36 @@ func marshalLanguageConfig(settings search.Settings, isVirtualIndex bool) []inte
 	return []interface{}{languageConfig}
 }
 
+// flattenDecompoundedAttributes returns the decompounded attributes sorted by language
+// since the API returns them as a map, whose iteration order is random.
+func flattenDecompoundedAttributes(decompoundedAttributes map[string][]string) []interface{} {
+	languages := make([]string, 0, len(decompoundedAttributes))
+	for language := range decompoundedAttributes {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	var flattened []interface{}
+	for _, language := range languages {
+		flattened = append(flattened, map[string]interface{}{
+			"language":   language,
+			"attributes": decompoundedAttributes[language],
+		})
+	}
+	return flattened
+}
+
+func flattenCustomNormalization(customNormalization map[string]map[string]string) []interface{} {
+	var customNormalizationByLanguage []interface{}
+	for language, normalization := range customNormalization {
+		customNormalizationByLanguage = append(customNormalizationByLanguage, map[string]interface{}{
+			"language":      language,
+			"normalization": normalization,
+		})
+	}
+	return customNormalizationByLanguage
+}
+
 func marshalQueryStrategyConfig(settings search.Settings, isVirtualIndex bool) []interface{} {
 	queryStrategyConfig := map[string]interface{}{
 		"query_type":                 settings.QueryType.Get(),
@@ -892,7 +1281,8 @@ func marshalAdvancedConfig(settings search.Settings, isVirtualIndex bool) []inte
 }
 
 func mapToIndexSettings(d *schema.ResourceData) search.Settings {
-	isVirtualIndex := d.Get("virtual").(bool)
+	// `virtual` is missing in the resources sharing the settings, e.g. algolia_localized_indices.
+	isVirtualIndex, _ := d.Get("virtual").(bool)
 
 	settings := search.Settings{}
 	if v, ok := d.GetOk("attributes_config"); ok {
@@ -904,8 +1294,11 @@ func mapToIndexSettings(d *schema.ResourceData) search.Settings {
 	if v, ok := d.GetOk("faceting_config"); ok {
 		unmarshalFacetingConfig(v, &settings)
 	}
+	if v, ok := d.GetOk("rendering_config"); ok {
+		unmarshalRenderingConfig(v, &settings)
+	}
 	if v, ok := d.GetOk("highlight_and_snippet_config"); ok {
-		unmarshalHighlightAndSnippetConfig(v, &settings)
+		unmarshalHighlightAndSnippetConfig(v, &settings, isVirtualIndex)
 	}
 	if v, ok := d.GetOk("pagination_config"); ok {
 		unmarshalPaginationConfig(v, &settings)
@@ -922,6 +1315,13 @@ func mapToIndexSettings(d *schema.ResourceData) search.Settings {
 	if v, ok := d.GetOk("enable_personalization"); ok {
 		settings.EnablePersonalization = opt.EnablePersonalization(v.(bool))
 	}
+	if v, ok := d.GetOk("mode"); ok {
+		if settings.CustomSettings == nil {
+			settings.CustomSettings = map[string]interface{}{}
+		}
+		// search.Settings doesn't support `mode`, so it's sent as a custom setting.
+		settings.CustomSettings["mode"] = v.(string)
+	}
 	if v, ok := d.GetOk("query_strategy_config"); ok {
 		unmarshalQueryStrategyConfig(v, &settings, isVirtualIndex)
 	}
@@ -959,6 +1359,11 @@ func unmarshalRankingConfig(configured interface{}, settings *search.Settings, i
 	settings.RelevancyStrictness = opt.RelevancyStrictness(config["relevancy_strictness"].(int))
 	if !isVirtualIndex {
 		settings.Ranking = opt.Ranking(castStringList(config["ranking"])...)
+		var reRankingApplyFilter []interface{}
+		for _, filters := range config["re_ranking_apply_filter"].([]interface{}) {
+			reRankingApplyFilter = append(reRankingApplyFilter, castStringList(filters))
+		}
+		settings.ReRankingApplyFilter = opt.ReRankingApplyFilterAnd(reRankingApplyFilter...)
 	}
 }
 
@@ -978,7 +1383,90 @@ func unmarshalFacetingConfig(configured interface{}, settings *search.Settings)
 	}
 }
 
-func unmarshalHighlightAndSnippetConfig(configured interface{}, settings *search.Settings) {
+func unmarshalRenderingConfig(configured interface{}, settings *search.Settings) {
+	l := configured.([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return
+	}
+	config := l[0].(map[string]interface{})
+
+	content := renderingContent{}
+	if l, ok := config["facet_ordering"].([]interface{}); ok && len(l) > 0 && l[0] != nil {
+		facetOrderingConfig := l[0].(map[string]interface{})
+		facetOrdering := &renderingContentFacetOrdering{}
+		if l, ok := facetOrderingConfig["facets"].([]interface{}); ok && len(l) > 0 && l[0] != nil {
+			facetOrdering.Facets = &renderingContentFacetsOrder{
+				Order: castStringList(l[0].(map[string]interface{})["order"]),
+			}
+		}
+		if s, ok := facetOrderingConfig["values"].(*schema.Set); ok && s.Len() > 0 {
+			facetOrdering.Values = map[string]renderingContentFacetValuesOrder{}
+			for _, v := range s.List() {
+				valuesConfig := v.(map[string]interface{})
+				facetOrdering.Values[valuesConfig["facet"].(string)] = renderingContentFacetValuesOrder{
+					Order:           castStringList(valuesConfig["order"]),
+					SortRemainingBy: valuesConfig["sort_remaining_by"].(string),
+					Hide:            castStringList(valuesConfig["hide"]),
+				}
+			}
+		}
+		content.FacetOrdering = facetOrdering
+	}
+
+	if settings.CustomSettings == nil {
+		settings.CustomSettings = map[string]interface{}{}
+	}
+	settings.CustomSettings["renderingContent"] = content
+}
+
+// getMode returns `mode` of the settings. It's not returned for indices which have never set it, then it's
+// `keywordSearch` as the default of the API.
+func getMode(settings search.Settings) string {
+	if mode, ok := settings.CustomSettings["mode"].(string); ok && mode != "" {
+		return mode
+	}
+	return "keywordSearch"
+}
+
+// renderingContent mirrors `renderingContent` of the settings API.
+// search.RenderingContent doesn't support `hide`, so it's sent and read through search.Settings.CustomSettings instead.
+type renderingContent struct {
+	FacetOrdering *renderingContentFacetOrdering `json:"facetOrdering,omitempty"`
+}
+
+type renderingContentFacetOrdering struct {
+	Facets *renderingContentFacetsOrder                `json:"facets,omitempty"`
+	Values map[string]renderingContentFacetValuesOrder `json:"values,omitempty"`
+}
+
+type renderingContentFacetsOrder struct {
+	Order []string `json:"order"`
+}
+
+type renderingContentFacetValuesOrder struct {
+	Order           []string `json:"order,omitempty"`
+	SortRemainingBy string   `json:"sortRemainingBy,omitempty"`
+	Hide            []string `json:"hide,omitempty"`
+}
+
+// getRenderingContent returns `renderingContent` of the settings, or nil if it's not set.
+func getRenderingContent(settings search.Settings) *renderingContent {
+	var v interface{} = settings.RenderingContent
+	if custom, ok := settings.CustomSettings["renderingContent"]; ok {
+		v = custom
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var content *renderingContent
+	if err := json.Unmarshal(b, &content); err != nil {
+		return nil
+	}
+	return content
+}
+
+func unmarshalHighlightAndSnippetConfig(configured interface{}, settings *search.Settings, isVirtualIndex bool) {
 	l := configured.([]interface{})
 	if len(l) == 0 || l[0] == nil {
 		return
@@ -987,7 +1475,12 @@ func unmarshalHighlightAndSnippetConfig(configured interface{}, settings *search
 	config := l[0].(map[string]interface{})
 
 	if v, ok := config["attributes_to_highlight"]; ok {
-		settings.AttributesToHighlight = opt.AttributesToHighlight(castStringSet(v)...)
+		attributesToHighlight := castStringSet(v)
+		// The virtual index inherits `attributesToHighlight` of the primary index unless it's set,
+		// so the empty list isn't sent not to disable highlighting.
+		if !isVirtualIndex || len(attributesToHighlight) > 0 {
+			settings.AttributesToHighlight = opt.AttributesToHighlight(attributesToHighlight...)
+		}
 	}
 	if v, ok := config["attributes_to_snippet"]; ok {
 		settings.AttributesToSnippet = opt.AttributesToSnippet(castStringSet(v)...)
@@ -1109,7 +1602,14 @@ func unmarshalLanguagesConfig(configured interface{}, settings *search.Settings,
 		if v, ok := config["decompounded_attributes"]; ok {
 			unmarshalLanguagesConfigDecompoundedAttributes(v, settings)
 		}
-		if v, ok := config["custom_normalization"]; ok {
+		if v, ok := config["custom_normalization_by_language"]; ok && v.(*schema.Set).Len() > 0 {
+			customNormalization := map[string]map[string]string{}
+			for _, bucket := range v.(*schema.Set).List() {
+				bucket := bucket.(map[string]interface{})
+				customNormalization[bucket["language"].(string)] = castStringMap(bucket["normalization"])
+			}
+			settings.CustomNormalization = opt.CustomNormalization(customNormalization)
+		} else if v, ok := config["custom_normalization"]; ok {
 			settings.CustomNormalization = opt.CustomNormalization(map[string]map[string]string{"default": castStringMap(v)})
 		}
 		if v, ok := config["index_languages"]; ok {
@@ -1225,6 +1725,870 @@ func unmarshalAdvancedConfig(configured interface{}, settings *search.Settings,
 	}
 }
 
+// facetAttributeModifiers is the list of modifiers which can wrap an attribute in `attributesForFaceting`.
+var facetAttributeModifiers = []string{"searchable", "filterOnly", "afterDistinct"}
+
+// normalizeFacetAttribute returns the canonical form of the given attribute for faceting.
+// e.g. `filteronly( brand )` => `filterOnly(brand)`
+func normalizeFacetAttribute(attribute string) string {
+	attribute = strings.TrimSpace(attribute)
+	open := strings.Index(attribute, "(")
+	if open <= 0 || !strings.HasSuffix(attribute, ")") {
+		return attribute
+	}
+	modifier := strings.TrimSpace(attribute[:open])
+	for _, m := range facetAttributeModifiers {
+		if strings.EqualFold(modifier, m) {
+			return fmt.Sprintf("%s(%s)", m, normalizeFacetAttribute(attribute[open+1:len(attribute)-1]))
+		}
+	}
+	return attribute
+}
+
+// validateFacetAttribute rejects the attribute for faceting which isn't in the canonical form,
+// since Algolia returns the canonical form and the configured one would never match it.
+func validateFacetAttribute(v interface{}, k string) ([]string, []error) {
+	attribute, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if normalized := normalizeFacetAttribute(attribute); normalized != attribute {
+		return nil, []error{fmt.Errorf("%s '%s' must be written as '%s'", k, attribute, normalized)}
+	}
+	return nil, nil
+}
+
+func validateVirtualIndexHasPrimary(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("virtual").(bool) || !d.NewValueKnown("primary_index_name") {
+		return nil
+	}
+	if d.Get("primary_index_name").(string) == "" {
+		return errors.New("`primary_index_name` is required when `virtual` is true")
+	}
+	return nil
+}
+
+func validateSearchableAttributesNotDuplicated(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("attributes_config.0.searchable_attributes") {
+		return nil
+	}
+	return findDuplicatedSearchableAttribute(castStringList(d.Get("attributes_config.0.searchable_attributes")))
+}
+
+func validateRankingNotDuplicated(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("ranking_config.0.ranking") {
+		return nil
+	}
+	return findDuplicatedRankingCriterion(castStringList(d.Get("ranking_config.0.ranking")))
+}
+
+// validateLanguageSettingsNotConflicting returns an error if both the boolean and the per-language form of a setting are enabled,
+// or both the `default` bucket and the per-language form of the custom normalization are set.
+// Unlike ConflictsWith, it accepts the disabled boolean form with languages (and vice versa) and the empty forms, which is how the index
+// data source exposes the settings, so that its output can be assigned to the resource as it is.
+func validateLanguageSettingsNotConflicting(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("languages_config") {
+		return nil
+	}
+	for _, keys := range [][2]string{
+		{"ignore_plurals", "ignore_plurals_for"},
+		{"remove_stop_words", "remove_stop_words_for"},
+	} {
+		enabled := d.Get(fmt.Sprintf("languages_config.0.%s", keys[0])).(bool)
+		languages := castStringSet(d.Get(fmt.Sprintf("languages_config.0.%s", keys[1])))
+		if enabled && len(languages) > 0 {
+			return fmt.Errorf("`%s` can't be true when `%s` is set", keys[0], keys[1])
+		}
+	}
+	customNormalization := d.Get("languages_config.0.custom_normalization").(map[string]interface{})
+	customNormalizationByLanguage := d.Get("languages_config.0.custom_normalization_by_language").(*schema.Set)
+	if len(customNormalization) > 0 && customNormalizationByLanguage.Len() > 0 {
+		return fmt.Errorf("`custom_normalization` can't be set when `custom_normalization_by_language` is set, set it as the `default` language bucket instead")
+	}
+	return nil
+}
+
+// warnUnretrievableAttributesToRetrieve warns when attributes are listed in both `attributes_to_retrieve` and
+// `unretrievable_attributes`. Such attributes are never retrieved since `unretrievable_attributes` takes precedence.
+func warnUnretrievableAttributesToRetrieve(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("attributes_config") {
+		return nil
+	}
+	attributesToRetrieve := castStringSet(d.Get("attributes_config.0.attributes_to_retrieve"))
+	unretrievableAttributes := castStringSet(d.Get("attributes_config.0.unretrievable_attributes"))
+	if attributes := intersectStrings(attributesToRetrieve, unretrievableAttributes); len(attributes) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("attributes (%s) of index (%s) are listed in both `attributes_to_retrieve` and `unretrievable_attributes`, they are never retrieved.", strings.Join(attributes, ", "), d.Get("name").(string)))
+	}
+	return nil
+}
+
+// warnEmptySearchableAttributes warns when `attributes_config` is configured without `searchable_attributes`,
+// which makes all attributes searchable with the same priority. It's checked only when `attributes_config` is
+// created or changed, not to warn on every plan.
+func warnEmptySearchableAttributes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("virtual").(bool) || !d.NewValueKnown("attributes_config") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("attributes_config") {
+		return nil
+	}
+	if len(d.Get("attributes_config").([]interface{})) == 0 {
+		return nil
+	}
+	if len(d.Get("attributes_config.0.searchable_attributes").([]interface{})) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("`searchable_attributes` of index (%s) is empty, so all attributes are searchable unordered. Consider listing the attributes to search in, especially for large records.", d.Get("name").(string)))
+	}
+	return nil
+}
+
+// intersectStrings returns the sorted strings contained in both a and b.
+func intersectStrings(a []string, b []string) []string {
+	inB := map[string]bool{}
+	for _, s := range b {
+		inB[s] = true
+	}
+	var intersection []string
+	for _, s := range a {
+		if inB[s] {
+			intersection = append(intersection, s)
+		}
+	}
+	sort.Strings(intersection)
+	return intersection
+}
+
+// warnFacetFiltersWithoutAttributesForFaceting warns when the rules of the existing index use facet filters
+// while no attributes for faceting are configured. It's best-effort and never blocks the plan:
+// the rules are looked up only when `attributes_for_faceting` is changed not to browse them on every plan.
+func warnFacetFiltersWithoutAttributesForFaceting(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// rules can't be attached to the index which doesn't exist yet.
+	if d.Id() == "" || d.Get("virtual").(bool) || !d.NewValueKnown("attributes_config") {
+		return nil
+	}
+	if !d.HasChange("attributes_config.0.attributes_for_faceting") {
+		return nil
+	}
+	if len(castStringSet(d.Get("attributes_config.0.attributes_for_faceting"))) > 0 {
+		return nil
+	}
+
+	apiClient, ok := m.(*apiClient)
+	if !ok {
+		return nil
+	}
+	ruleIDs, err := findRulesUsingFacetFilters(ctx, apiClient.searchClient.InitIndex(d.Id()))
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to check rules of index (%s) using facet filters: %v", d.Id(), err))
+		return nil
+	}
+	if len(ruleIDs) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("rules (%s) of index (%s) use facet filters, but `attributes_for_faceting` is empty. The facet filters won't work unless the attributes are declared for faceting.", strings.Join(ruleIDs, ", "), d.Id()))
+	}
+	return nil
+}
+
+// findRulesUsingFacetFilters returns the object IDs of the rules whose consequence uses facet filters.
+func findRulesUsingFacetFilters(ctx context.Context, index searchIndex) ([]string, error) {
+	it, err := index.BrowseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleIDs []string
+	for {
+		rule, err := it.Next()
+		if err == io.EOF {
+			return ruleIDs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		params := rule.Consequence.Params
+		if params == nil {
+			continue
+		}
+		if len(params.AutomaticFacetFilters) > 0 || len(params.AutomaticOptionalFacetFilters) > 0 || len(params.FacetFilters.Get()) > 0 {
+			ruleIDs = append(ruleIDs, rule.ObjectID)
+		}
+	}
+}
+
+// ruleFacetPlaceholderRegexp matches the facet value placeholders (e.g. `{facet:brand}`) in the patterns of rule conditions.
+var ruleFacetPlaceholderRegexp = regexp.MustCompile(`\{facet:([^}]+)\}`)
+
+// warnRuleFacetsNotInAttributesForFaceting warns when the rules of the index use facet value placeholders in their
+// conditions for attributes missing from `attributes_for_faceting`, since such conditions never match.
+// It's best-effort: the rules are looked up only when `attributes_for_faceting` is changed not to call API on every plan,
+// and an empty `attributes_for_faceting` is left to warnFacetFiltersWithoutAttributesForFaceting.
+func warnRuleFacetsNotInAttributesForFaceting(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// rules can't be attached to the index which doesn't exist yet.
+	if d.Id() == "" || d.Get("virtual").(bool) || !d.NewValueKnown("attributes_config") {
+		return nil
+	}
+	if !d.HasChange("attributes_config.0.attributes_for_faceting") {
+		return nil
+	}
+	attributesForFaceting := castStringSet(d.Get("attributes_config.0.attributes_for_faceting"))
+	if len(attributesForFaceting) == 0 {
+		return nil
+	}
+
+	apiClient := m.(*apiClient)
+	facetsByRuleID, err := findRuleFacetPlaceholders(ctx, apiClient.searchClient.InitIndex(d.Id()))
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to check facet placeholders of rules of index (%s): %v", d.Id(), err))
+		return nil
+	}
+	ruleIDs := make([]string, 0, len(facetsByRuleID))
+	for ruleID := range facetsByRuleID {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+	for _, ruleID := range ruleIDs {
+		for _, facet := range facetsByRuleID[ruleID] {
+			if isFacetDeclared(facet, attributesForFaceting) {
+				continue
+			}
+			tflog.Warn(ctx, fmt.Sprintf("rule (%s) of index (%s) uses `{facet:%s}` in its condition, but `%s` is not in `attributes_for_faceting`. The condition never matches unless the attribute is declared for faceting.", ruleID, d.Id(), facet, facet))
+		}
+	}
+	return nil
+}
+
+// findRuleFacetPlaceholders returns the attributes used by the facet value placeholders in the conditions of the rules, by the object ID of the rule.
+func findRuleFacetPlaceholders(ctx context.Context, index searchIndex) (map[string][]string, error) {
+	it, err := index.BrowseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	facetsByRuleID := map[string][]string{}
+	for {
+		rule, err := it.Next()
+		if err == io.EOF {
+			return facetsByRuleID, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, condition := range rule.Conditions {
+			for _, match := range ruleFacetPlaceholderRegexp.FindAllStringSubmatch(condition.Pattern, -1) {
+				facetsByRuleID[rule.ObjectID] = append(facetsByRuleID[rule.ObjectID], strings.TrimSpace(match[1]))
+			}
+		}
+	}
+}
+
+// isFacetDeclared returns whether the attribute is declared in the attributes for faceting, ignoring the modifiers.
+// The nested attributes of a declared attribute are regarded as declared to avoid false positives.
+func isFacetDeclared(facet string, attributesForFaceting []string) bool {
+	for _, attribute := range attributesForFaceting {
+		attribute = unwrapFacetAttribute(normalizeFacetAttribute(attribute))
+		if facet == attribute || strings.HasPrefix(facet, attribute+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapFacetAttribute removes the modifiers from the normalized attribute for faceting.
+// e.g. `filterOnly(brand)` => `brand`
+func unwrapFacetAttribute(attribute string) string {
+	for _, m := range facetAttributeModifiers {
+		if strings.HasPrefix(attribute, m+"(") && strings.HasSuffix(attribute, ")") {
+			return unwrapFacetAttribute(attribute[len(m)+1 : len(attribute)-1])
+		}
+	}
+	return attribute
+}
+
+// warnInconsistentFacetValuesSort warns when the per-facet sort order in `renderingContent` of the existing index
+// differs from `sort_facet_values_by`. It's best-effort and never blocks the plan.
+func warnInconsistentFacetValuesSort(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("faceting_config") {
+		return nil
+	}
+	// The settings are fetched only when the sorts change, not to call the API on every plan.
+	if !d.HasChange("faceting_config.0.sort_facet_values_by") && !d.HasChange("rendering_config") {
+		return nil
+	}
+	sortFacetValuesBy := d.Get("faceting_config.0.sort_facet_values_by").(string)
+	if sortFacetValuesBy == "" {
+		return nil
+	}
+
+	apiClient, ok := m.(*apiClient)
+	if !ok {
+		return nil
+	}
+	settings, err := apiClient.searchClient.InitIndex(d.Id()).GetSettings(ctx)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to get settings of index (%s) to check facet values sort: %v", d.Id(), err))
+		return nil
+	}
+	if facets := findFacetsWithDifferentSort(sortFacetValuesBy, settings.RenderingContent); len(facets) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("facets (%s) of index (%s) are sorted differently from `sort_facet_values_by` (%s) by `renderingContent`, which takes precedence in the search UI.", strings.Join(facets, ", "), d.Id(), sortFacetValuesBy))
+	}
+	return nil
+}
+
+// sortFacetValuesByValues are the values of `sortFacetValuesBy` supported by the provider.
+var sortFacetValuesByValues = []string{"alpha", "count"}
+
+// warnUnexpectedSortFacetValuesBy warns when the index has a `sortFacetValuesBy` which isn't supported by the provider, e.g. set from the dashboard.
+// The value is read as it is to surface the drift, and it's overwritten with the configured one on apply.
+func warnUnexpectedSortFacetValuesBy(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("faceting_config") {
+		return nil
+	}
+	o, n := d.GetChange("faceting_config.0.sort_facet_values_by")
+	current := o.(string)
+	if current == "" || current == n.(string) {
+		return nil
+	}
+	for _, v := range sortFacetValuesByValues {
+		if current == v {
+			return nil
+		}
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("`sortFacetValuesBy` of index (%s) is `%s`, which isn't supported by the provider. It will be overwritten with `%s`.", d.Id(), current, n.(string)))
+	return nil
+}
+
+// findFacetsWithDifferentSort returns the facets whose `sortRemainingBy` in renderingContent differs from sortFacetValuesBy.
+// Facets with `hidden` are ignored since they don't have a counterpart in `sortFacetValuesBy`.
+func findFacetsWithDifferentSort(sortFacetValuesBy string, renderingContent *search.RenderingContent) []string {
+	if renderingContent == nil || renderingContent.FacetOrdering == nil {
+		return nil
+	}
+
+	var facets []string
+	for facet, order := range renderingContent.FacetOrdering.Values {
+		if order.SortRemainingBy == nil || *order.SortRemainingBy == search.Hidden {
+			continue
+		}
+		if string(*order.SortRemainingBy) != sortFacetValuesBy {
+			facets = append(facets, facet)
+		}
+	}
+	sort.Strings(facets)
+	return facets
+}
+
+// warnPrimaryIndexNameChange explains the implications of changing `primary_index_name`, which recreates the index.
+func warnPrimaryIndexNameChange(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("primary_index_name") {
+		return nil
+	}
+	old, new := d.GetChange("primary_index_name")
+	tflog.Warn(ctx, fmt.Sprintf("changing `primary_index_name` of index (%s) from '%s' to '%s' forces the index to be deleted and recreated. All records of the index are deleted, and the index is unavailable until it's recreated. `deletion_protection` must be false to apply the change.", d.Id(), old, new))
+	return nil
+}
+
+// warnReplicaWithoutSortCriteria warns when a standard replica has neither `custom_ranking` nor a sort criterion
+// in `ranking`, since such a replica returns results in the same order as its primary index.
+func warnReplicaWithoutSortCriteria(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("virtual").(bool) {
+		return nil
+	}
+	if d.NewValueKnown("primary_index_name") && d.Get("primary_index_name").(string) == "" {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("primary_index_name") && !d.HasChange("ranking_config") {
+		return nil
+	}
+
+	// ranking_config is unknown only when it's not configured for the new index.
+	if d.NewValueKnown("ranking_config") {
+		if len(castStringList(d.Get("ranking_config.0.custom_ranking"))) > 0 {
+			return nil
+		}
+		for _, criterion := range castStringList(d.Get("ranking_config.0.ranking")) {
+			if strings.HasPrefix(criterion, "asc(") || strings.HasPrefix(criterion, "desc(") {
+				return nil
+			}
+		}
+	}
+	tflog.Warn(ctx, fmt.Sprintf("replica index (%s) has neither `custom_ranking` nor a sort criterion (`asc()` or `desc()`) in `ranking`, so it sorts results in the same way as the primary index.", d.Get("name").(string)))
+	return nil
+}
+
+// warnTypoSettingsWithoutTypoTolerance warns when typo related settings are configured while typo tolerance is disabled,
+// since they have no effect then. Settings with the default values are ignored.
+func warnTypoSettingsWithoutTypoTolerance(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("typos_config") || d.Get("typos_config.0.typo_tolerance").(string) != "false" {
+		return nil
+	}
+
+	var inertSettings []string
+	if d.Get("typos_config.0.min_word_size_for_1_typo").(int) != 4 {
+		inertSettings = append(inertSettings, "min_word_size_for_1_typo")
+	}
+	if d.Get("typos_config.0.min_word_size_for_2_typos").(int) != 8 {
+		inertSettings = append(inertSettings, "min_word_size_for_2_typos")
+	}
+	if !d.Get("typos_config.0.allow_typos_on_numeric_tokens").(bool) {
+		inertSettings = append(inertSettings, "allow_typos_on_numeric_tokens")
+	}
+	if len(castStringList(d.Get("typos_config.0.disable_typo_tolerance_on_attributes"))) > 0 {
+		inertSettings = append(inertSettings, "disable_typo_tolerance_on_attributes")
+	}
+	if len(castStringList(d.Get("typos_config.0.disable_typo_tolerance_on_words"))) > 0 {
+		inertSettings = append(inertSettings, "disable_typo_tolerance_on_words")
+	}
+	if len(inertSettings) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("`typo_tolerance` of index (%s) is false, so `%s` have no effect.", d.Get("name").(string), strings.Join(inertSettings, "`, `")))
+	}
+	return nil
+}
+
+// warnSuspiciousHighlightTags warns when `highlight_pre_tag` and `highlight_post_tag` don't make a pair,
+// since the highlighted parts can't be told apart or the HTML of the results breaks.
+func warnSuspiciousHighlightTags(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("highlight_and_snippet_config") {
+		return nil
+	}
+
+	preTag := d.Get("highlight_and_snippet_config.0.highlight_pre_tag").(string)
+	postTag := d.Get("highlight_and_snippet_config.0.highlight_post_tag").(string)
+	for _, issue := range findHighlightTagIssues(preTag, postTag) {
+		tflog.Warn(ctx, fmt.Sprintf("highlight tags of index (%s) look suspicious: %s.", d.Get("name").(string), issue))
+	}
+	return nil
+}
+
+// warnSnippetWithoutHighlight warns when `attributes_to_snippet` is set but `attributes_to_highlight` is empty,
+// since the snippets aren't highlighted then, which is valid but often unintended.
+// It's skipped for a virtual index, which inherits `attributes_to_highlight` of the primary index when it's empty.
+func warnSnippetWithoutHighlight(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("highlight_and_snippet_config") || d.Get("virtual").(bool) {
+		return nil
+	}
+	if len(castStringSet(d.Get("highlight_and_snippet_config.0.attributes_to_snippet"))) == 0 {
+		return nil
+	}
+	if len(castStringSet(d.Get("highlight_and_snippet_config.0.attributes_to_highlight"))) > 0 {
+		return nil
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("`attributes_to_snippet` of index (%s) is set but `attributes_to_highlight` is empty, so the snippets won't be highlighted. Set `attributes_to_highlight` if it's unintended.", d.Get("name").(string)))
+	return nil
+}
+
+// validateQueryType validates `query_type`, and warns about `prefixAll` in the plan output since Algolia discourages it for its performance cost.
+func validateQueryType(v interface{}, path cty.Path) diag.Diagnostics {
+	diags := validation.ToDiagFunc(validation.StringInSlice([]string{"prefixLast", "prefixAll", "prefixNone"}, false))(v, path)
+	if v.(string) == "prefixAll" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "`query_type` is `prefixAll`",
+			Detail:        "`prefixAll` interprets all the query words as prefixes. It can significantly increase the search latency and return less relevant results, so consider `prefixLast` unless it's really needed. See https://www.algolia.com/doc/api-reference/api-parameters/queryType/.",
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// validateAllowCompressionOfIntegerArray warns about `allow_compression_of_integer_array` in the plan output when it's enabled,
+// since the compressed arrays must contain only integers.
+func validateAllowCompressionOfIntegerArray(v interface{}, path cty.Path) diag.Diagnostics {
+	if !v.(bool) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "`allow_compression_of_integer_array` is enabled",
+		Detail:        "It only works for the arrays containing integers only, and the order of the compressed arrays isn't kept. Make sure no record has the arrays mixed with other types of values. See https://www.algolia.com/doc/api-reference/api-parameters/allowCompressionOfIntegerArray/.",
+		AttributePath: path,
+	}}
+}
+
+// warnAllOptionalWithOptionalWords warns when `remove_words_if_no_results` is `allOptional` and `optional_words` are set,
+// since all the query words become optional when the query has no results, which can make `optional_words` look ineffective.
+// It's only informational and doesn't block the plan.
+func warnAllOptionalWithOptionalWords(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("query_strategy_config") || d.Get("query_strategy_config.0.remove_words_if_no_results").(string) != "allOptional" {
+		return nil
+	}
+	if len(castStringSet(d.Get("query_strategy_config.0.optional_words"))) == 0 {
+		return nil
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("`remove_words_if_no_results` of index (%s) is `allOptional` and `optional_words` are set. When the query has no results, all the query words become optional, not only `optional_words`, so the results may match only a few of the query words. See https://www.algolia.com/doc/api-reference/api-parameters/removeWordsIfNoResults/.", d.Get("name").(string)))
+	return nil
+}
+
+// findHighlightTagIssues returns the issues of the pair of the highlight tags, such as identical tags.
+// Identical tags are allowed when they aren't HTML (e.g. `**` for Markdown).
+func findHighlightTagIssues(preTag, postTag string) []string {
+	if !strings.HasPrefix(preTag, "<") || !strings.HasPrefix(postTag, "<") {
+		return nil
+	}
+	if preTag == postTag {
+		return []string{fmt.Sprintf("`highlight_pre_tag` and `highlight_post_tag` are the same HTML tag '%s'", preTag)}
+	}
+	if !strings.HasPrefix(postTag, "</") {
+		return []string{fmt.Sprintf("`highlight_post_tag` '%s' doesn't close `highlight_pre_tag` '%s'", postTag, preTag)}
+	}
+	return nil
+}
+
+// validateHighlightTag validates the highlight tag, and warns in the plan output when its angle brackets are unbalanced
+// since the HTML of the results breaks then.
+func validateHighlightTag(v interface{}, path cty.Path) diag.Diagnostics {
+	diags := validation.ToDiagFunc(validation.StringIsNotWhiteSpace)(v, path)
+	if tag, ok := v.(string); ok && strings.Count(tag, "<") != strings.Count(tag, ">") {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Highlight tag has unbalanced angle brackets",
+			Detail:        fmt.Sprintf("'%s' has unbalanced angle brackets, which breaks the HTML of the highlighted results.", tag),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// warnPaginationLimitedToLowered warns when `pagination_limited_to` is lowered or is lower than `hits_per_page`,
+// since the hits beyond it are no longer accessible via pagination.
+func warnPaginationLimitedToLowered(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("pagination_config") || !d.HasChange("pagination_config.0.pagination_limited_to") {
+		return nil
+	}
+	old, new := d.GetChange("pagination_config.0.pagination_limited_to")
+	paginationLimitedTo := new.(int)
+	hitsPerPage := d.Get("pagination_config.0.hits_per_page").(int)
+	if d.Id() != "" && old.(int) > paginationLimitedTo {
+		tflog.Warn(ctx, fmt.Sprintf("`pagination_limited_to` of index (%s) is lowered from %d to %d, the hits beyond it are no longer accessible via pagination.", d.Id(), old.(int), paginationLimitedTo))
+	} else if paginationLimitedTo < hitsPerPage {
+		tflog.Warn(ctx, fmt.Sprintf("`pagination_limited_to` (%d) of index (%s) is lower than `hits_per_page` (%d), only the first page is partially accessible.", paginationLimitedTo, d.Get("name").(string), hitsPerPage))
+	}
+	return nil
+}
+
+// validateMinWordSizesForTypos returns an error if the word size to accept 1 typo is larger than the one for 2 typos,
+// which silently degrades typo tolerance.
+func validateMinWordSizesForTypos(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("typos_config") {
+		return nil
+	}
+	minWordSizeFor1Typo := d.Get("typos_config.0.min_word_size_for_1_typo").(int)
+	minWordSizeFor2Typos := d.Get("typos_config.0.min_word_size_for_2_typos").(int)
+	if minWordSizeFor1Typo == 0 || minWordSizeFor2Typos == 0 {
+		return nil
+	}
+	if minWordSizeFor1Typo > minWordSizeFor2Typos {
+		return fmt.Errorf("`min_word_size_for_1_typo` (%d) must not be larger than `min_word_size_for_2_typos` (%d)", minWordSizeFor1Typo, minWordSizeFor2Typos)
+	}
+	if minWordSizeFor1Typo == minWordSizeFor2Typos {
+		tflog.Warn(ctx, fmt.Sprintf("`min_word_size_for_1_typo` and `min_word_size_for_2_typos` are the same (%d), words shorter than it accept no typo and the others accept 2 typos.", minWordSizeFor1Typo))
+	}
+	return nil
+}
+
+var validTypoTolerances = []string{"true", "false", "min", "strict"}
+
+// validateTypoTolerance validates `typo_tolerance` with the message distinguishing it from the numeric typo settings,
+// since the numeric value (e.g. "1") is often passed by mistake.
+func validateTypoTolerance(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	for _, typoTolerance := range validTypoTolerances {
+		if v == typoTolerance {
+			return nil, nil
+		}
+	}
+	if _, err := strconv.Atoi(v); err == nil {
+		return nil, []error{fmt.Errorf("expected %s to be one of %q, got %q. %s is not a number of typos, use `min_word_size_for_1_typo` and `min_word_size_for_2_typos` to configure the word sizes to accept typos", k, validTypoTolerances, v, k)}
+	}
+	return nil, []error{fmt.Errorf("expected %s to be one of %q, got %q", k, validTypoTolerances, v)}
+}
+
+// warnPersonalizationWithoutStrategy warns when personalization is being enabled while no personalization strategy
+// is configured for the application in `personalization_region` of the provider. It's best-effort and never blocks the plan.
+func warnPersonalizationWithoutStrategy(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// only check when it's being enabled not to call API on every plan.
+	if !d.NewValueKnown("enable_personalization") || !d.Get("enable_personalization").(bool) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("enable_personalization") {
+		return nil
+	}
+
+	apiClient, ok := m.(*apiClient)
+	if !ok {
+		return nil
+	}
+	strategy, err := apiClient.newPersonalizationClient(apiClient.personalizationRegion).GetPersonalizationStrategy(ctx)
+	if err != nil && !algoliautil.IsNotFoundError(err) {
+		tflog.Debug(ctx, fmt.Sprintf("failed to get personalization strategy: %v", err))
+		return nil
+	}
+	if isPersonalizationStrategyEmpty(strategy) {
+		tflog.Warn(ctx, fmt.Sprintf("`enable_personalization` is true for index (%s), but no personalization strategy is configured for the application. Personalization has no effect until the strategy is configured.", d.Get("name").(string)))
+	}
+	return nil
+}
+
+// warnLanguageFeaturesWithoutQueryLanguages warns when language-specific features are enabled without
+// `query_languages`, since they have no effect for the query then. It never blocks the plan.
+func warnLanguageFeaturesWithoutQueryLanguages(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("languages_config") {
+		return nil
+	}
+	if queryLanguages, ok := d.Get("languages_config.0.query_languages").(*schema.Set); ok && queryLanguages.Len() > 0 {
+		return nil
+	}
+
+	var features []string
+	if d.Get("languages_config.0.ignore_plurals").(bool) {
+		features = append(features, "ignore_plurals")
+	}
+	if d.Get("languages_config.0.remove_stop_words").(bool) {
+		features = append(features, "remove_stop_words")
+	}
+	if d.Get("languages_config.0.decompound_query").(bool) && len(d.Get("languages_config.0.decompounded_attributes").([]interface{})) > 0 {
+		features = append(features, "decompound_query")
+	}
+	if len(features) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("`%s` of index (%s) is enabled without `query_languages`. Set `query_languages` to apply it to the intended languages.", strings.Join(features, "`, `"), d.Get("name").(string)))
+	}
+	return nil
+}
+
+// decompoundingLanguages are the languages which support decompounding.
+// https://www.algolia.com/doc/api-reference/api-parameters/decompoundQuery/
+var decompoundingLanguages = []string{"da", "de", "fi", "nl", "no", "sv"}
+
+// warnDecompoundQueryWithoutDecompoundingLanguages warns when `decompound_query` is enabled for `decompounded_attributes`,
+// but none of `query_languages` and `index_languages` supports decompounding, since the query isn't decompounded then.
+// `decompound_query` is enabled by default, so it's only checked when `decompounded_attributes` are set. The case without
+// `query_languages` is covered by warnLanguageFeaturesWithoutQueryLanguages. It never blocks the plan.
+func warnDecompoundQueryWithoutDecompoundingLanguages(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("languages_config") || !d.Get("languages_config.0.decompound_query").(bool) {
+		return nil
+	}
+	if len(d.Get("languages_config.0.decompounded_attributes").([]interface{})) == 0 {
+		return nil
+	}
+	queryLanguages := castStringSet(d.Get("languages_config.0.query_languages"))
+	if len(queryLanguages) == 0 {
+		return nil
+	}
+	languages := append(queryLanguages, castStringSet(d.Get("languages_config.0.index_languages"))...)
+	if len(intersectStrings(languages, decompoundingLanguages)) > 0 {
+		return nil
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("`decompound_query` of index (%s) is enabled, but none of `query_languages` and `index_languages` (%s) supports decompounding, so the query isn't decompounded. Add one of %s to `query_languages` to decompound the query.", d.Get("name").(string), strings.Join(languages, ", "), strings.Join(decompoundingLanguages, ", ")))
+	return nil
+}
+
+func isPersonalizationStrategyEmpty(strategy personalization.Strategy) bool {
+	return len(strategy.EventsScoring) == 0 && len(strategy.FacetsScoring) == 0
+}
+
+// findDuplicatedSearchableAttribute returns an error if the same attribute appears more than once.
+// Attributes with the same priority (e.g. `title,alternative_title`) and `unordered()` modifier are taken into account.
+func findDuplicatedSearchableAttribute(searchableAttributes []string) error {
+	seen := map[string]string{}
+	for _, searchableAttribute := range searchableAttributes {
+		for _, attribute := range strings.Split(searchableAttribute, ",") {
+			name := strings.TrimSpace(attribute)
+			if strings.HasPrefix(name, "unordered(") && strings.HasSuffix(name, ")") {
+				name = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(name, "unordered("), ")"))
+			}
+			if name == "" {
+				continue
+			}
+			if entry, ok := seen[name]; ok {
+				return fmt.Errorf("attribute '%s' is duplicated in `searchable_attributes`: '%s' and '%s'", name, entry, searchableAttribute)
+			}
+			seen[name] = searchableAttribute
+		}
+	}
+	return nil
+}
+
+// findDuplicatedRankingCriterion returns an error if the same ranking criterion appears more than once.
+func findDuplicatedRankingCriterion(ranking []string) error {
+	seen := map[string]bool{}
+	for _, criterion := range ranking {
+		if seen[criterion] {
+			return fmt.Errorf("ranking criterion '%s' is duplicated in `ranking`", criterion)
+		}
+		seen[criterion] = true
+	}
+	return nil
+}
+
+func saveSeedObjects(ctx context.Context, index searchIndex, seedObjectsJSON string) error {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal([]byte(seedObjectsJSON), &objects); err != nil {
+		return fmt.Errorf("failed to unmarshal seed objects: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	res, err := index.SaveObjects(objects, opt.AutoGenerateObjectIDIfNotExist(true), ctx)
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	if err := res.Wait(); err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	return nil
+}
+
+// diffSeedObjectsSuppress suppresses the diff of `seed_objects_json` for existing indices
+// since the seed objects are saved only on creation.
+func diffSeedObjectsSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// setIndexSettings updates the settings of the index and waits for the task to finish if waitForTask is true.
+// When forwardToReplicas is true, the settings listed in ignoredSettingsOnReplica are applied only to the index.
+func setIndexSettings(index searchIndex, settings search.Settings, forwardToReplicas bool, ignoredSettingsOnReplica []string, waitForTask bool) error {
+	type setSettingsRequest struct {
+		settings search.Settings
+		opts     []interface{}
+	}
+	requests := []setSettingsRequest{{settings: settings}}
+	if forwardToReplicas {
+		forwarded, notForwarded, err := splitSettingsForReplicas(settings, ignoredSettingsOnReplica)
+		if err != nil {
+			return err
+		}
+		requests = []setSettingsRequest{{settings: forwarded, opts: []interface{}{opt.ForwardToReplicas(true)}}}
+		if notForwarded != nil {
+			requests = append(requests, setSettingsRequest{settings: *notForwarded})
+		}
+	}
+
+	for _, req := range requests {
+		res, err := index.SetSettings(req.settings, req.opts...)
+		if err != nil {
+			return algoliautil.HumanizeError(err)
+		}
+		if !waitForTask {
+			continue
+		}
+		if err := res.Wait(); err != nil {
+			return algoliautil.HumanizeError(err)
+		}
+	}
+	return nil
+}
+
+// shouldWaitForTask returns whether to wait for the settings update task of the index.
+func shouldWaitForTask(d *schema.ResourceData, apiClient *apiClient) bool {
+	var waitForTask *bool
+	if v := d.GetRawConfig(); !v.IsNull() && v.Type().HasAttribute("wait_for_task") && !v.GetAttr("wait_for_task").IsNull() {
+		b := d.Get("wait_for_task").(bool)
+		waitForTask = &b
+	}
+	return resolveWaitForTask(waitForTask, apiClient.waitForTask)
+}
+
+// resolveWaitForTask returns `wait_for_task` of the resource if it's configured, otherwise the provider default.
+func resolveWaitForTask(waitForTask *bool, providerDefault bool) bool {
+	if waitForTask != nil {
+		return *waitForTask
+	}
+	return providerDefault
+}
+
+// setIndexReplicas replaces the replicas of the index. The replicas setting is applied alone without being forwarded,
+// since it only makes sense for the index itself.
+func setIndexReplicas(ctx context.Context, index searchIndex, replicas []string, timeout time.Duration) error {
+	err := retryWrite(ctx, timeout, func() error {
+		res, err := index.SetSettings(search.Settings{
+			Replicas: opt.Replicas(replicas...),
+		}, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	return nil
+}
+
+// splitSettingsForReplicas splits the settings into the ones to be forwarded to the replicas and the others.
+// notForwarded is nil when none of the ignored settings is set.
+func splitSettingsForReplicas(settings search.Settings, ignoredSettingsOnReplica []string) (forwarded search.Settings, notForwarded *search.Settings, err error) {
+	if len(ignoredSettingsOnReplica) == 0 {
+		return settings, nil, nil
+	}
+
+	forwardedMap, err := settingsToRawMap(settings)
+	if err != nil {
+		return search.Settings{}, nil, err
+	}
+	notForwardedMap := map[string]json.RawMessage{}
+	for _, name := range ignoredSettingsOnReplica {
+		if v, ok := forwardedMap[name]; ok {
+			notForwardedMap[name] = v
+			delete(forwardedMap, name)
+		}
+	}
+	if len(notForwardedMap) == 0 {
+		return settings, nil, nil
+	}
+
+	if forwarded, err = unmarshalSettingsMap(forwardedMap); err != nil {
+		return search.Settings{}, nil, err
+	}
+	s, err := unmarshalSettingsMap(notForwardedMap)
+	if err != nil {
+		return search.Settings{}, nil, err
+	}
+	return forwarded, &s, nil
+}
+
+func settingsToRawMap(settings search.Settings) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	return m, nil
+}
+
+func unmarshalSettingsMap(m map[string]json.RawMessage) (search.Settings, error) {
+	var settings search.Settings
+	b, err := json.Marshal(m)
+	if err != nil {
+		return settings, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	return settings, nil
+}
+
+// algoliaSettingNames returns the API parameter names of the index settings supported by the client.
+func algoliaSettingNames() []string {
+	t := reflect.TypeOf(search.Settings{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
 func algoliaIndexMutexKey(appID string, indexName string) string {
 	return fmt.Sprintf("%s-algolia-index-%s", appID, indexName)
 }
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourceIndexBundle() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIndexBundleCreate,
+		ReadContext:   resourceIndexBundleRead,
+		UpdateContext: resourceIndexBundleUpdate,
+		DeleteContext: resourceIndexBundleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIndexBundleStateContext,
+		},
+		CustomizeDiff: customdiff.All(
+			validateRulesObjectIDsNotDuplicated,
+			validateRulesConditionsNotEmpty,
+			validateSynonymObjectIDsNotDuplicated,
+			validateOneWaySynonymsHaveInput,
+		),
+		Description: `A configuration for the settings, rules and synonyms of an index managed as one unit.
+
+The settings are applied first, then the synonyms and the rules, so that the rules can depend on the settings (e.g. ` + "`attributesForFaceting`" + `).
+All of them are applied while locking the index, so no other resource of this provider updates the index in the meantime.
+※ **It owns the index.** It replaces all the synonyms and the rules of the index, and deletes the index including its records on destroy.
+So don't use it together with ` + "`algolia_index`" + `, ` + "`algolia_rule(s)`" + ` or ` + "`algolia_synonym(s)`" + ` for the same index.
+`,
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(1 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index.",
+			},
+			"settings_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: diffJsonSuppress,
+				ValidateFunc:     validation.StringIsJSON,
+				Description: `Settings of the index in JSON format, as documented in the [API reference](https://www.algolia.com/doc/api-reference/settings-api-parameters/).
+Only the settings listed here are managed. The settings removed from it keep their current values in Algolia. All the settings are read on import.`,
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Rules of the index. The rules which aren't listed are deleted. See `rules` of `algolia_rules` for the details.",
+				Elem:        resourceRules().Schema["rules"].Elem,
+			},
+			"synonyms": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Synonyms of the index. The synonyms which aren't listed are deleted. See `synonyms` of `algolia_synonyms` for the details.",
+				Elem:        resourceSynonyms().Schema["synonyms"].Elem,
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to allow Terraform to destroy the index. Unless this field is set to false in Terraform state, a terraform destroy or terraform apply command that deletes the index will fail.",
+			},
+		},
+	}
+}
+
+func resourceIndexBundleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveIndexBundle(ctx, d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("name").(string))
+
+	return resourceIndexBundleRead(ctx, d, m)
+}
+
+func resourceIndexBundleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshIndexBundleState(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceIndexBundleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveIndexBundle(ctx, d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceIndexBundleRead(ctx, d, m)
+}
+
+func resourceIndexBundleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot destroy index without setting deletion_protection=false and running `terraform apply`")
+	}
+
+	apiClient := m.(*apiClient)
+
+	mutexKV.Lock(ctx, algoliaIndexMutexKey(apiClient.appID, d.Id()))
+	defer mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, d.Id()))
+
+	// Deleting the index deletes its settings, rules, synonyms and records at once.
+	res, err := apiClient.searchClient.InitIndex(d.Id()).Delete(ctx)
+	if err != nil {
+		// The index may have been deleted out of band.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	if err := res.Wait(ctx); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return nil
+}
+
+func resourceIndexBundleStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("name", d.Id()); err != nil {
+		return nil, err
+	}
+	// deletion_protection isn't stored in Algolia, so the default is set.
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+	if err := refreshIndexBundleState(ctx, d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("index (%s) is not found", d.Get("name").(string))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func refreshIndexBundleState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("name").(string)
+	index := apiClient.searchClient.InitIndex(indexName)
+	settings, err := index.GetSettings(ctx)
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("index (%s) not found, removing from state", indexName))
+			d.SetId("")
+			return nil
+		}
+		return algoliautil.HumanizeError(err)
+	}
+	settingsJSON, err := flattenIndexBundleSettings(settings, d.Get("settings_json").(string))
+	if err != nil {
+		return err
+	}
+
+	synonyms, err := listSynonyms(ctx, apiClient, indexName)
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+
+	rulesByObjectID, err := browseRules(ctx, index)
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	// All the rules of the index are read since the ones which aren't configured are deleted on apply.
+	rules, err := flattenRules(ctx, indexName, rulesByObjectID, d.Get("rules"), true)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{
+		"name":          indexName,
+		"settings_json": settingsJSON,
+		"synonyms":      synonyms,
+		"rules":         rules,
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// saveIndexBundle applies the settings, the synonyms and the rules in this order while locking the index.
+func saveIndexBundle(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("name").(string)
+	settings, err := unmarshalIndexBundleSettings(d.Get("settings_json").(string))
+	if err != nil {
+		return err
+	}
+	rules, err := mapToRules(d.Get("rules"))
+	if err != nil {
+		return err
+	}
+	synonyms := mapToSynonyms(d)
+
+	mutexKV.Lock(ctx, algoliaIndexMutexKey(apiClient.appID, indexName))
+	defer mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, indexName))
+
+	index := apiClient.searchClient.InitIndex(indexName)
+	err = retryWrite(ctx, timeout, func() error {
+		res, err := index.SetSettings(settings, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(fmt.Errorf("failed to set settings of index (%s): %w", indexName, err))
+	}
+
+	err = retryWrite(ctx, timeout, func() error {
+		res, err := index.ReplaceAllSynonyms(synonyms, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(fmt.Errorf("failed to save synonyms of index (%s): %w", indexName, err))
+	}
+
+	err = retryWrite(ctx, timeout, func() error {
+		res, err := index.SaveRules(rules, opt.ClearExistingRules(true), ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(fmt.Errorf("failed to save rules of index (%s): %w", indexName, err))
+	}
+
+	return nil
+}
+
+func unmarshalIndexBundleSettings(settingsJSON string) (search.Settings, error) {
+	var settings search.Settings
+	if settingsJSON == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(settingsJSON), &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings_json: %w", err)
+	}
+	return settings, nil
+}
+
+// flattenIndexBundleSettings returns the settings listed in the configured settings JSON, or all the settings
+// if nothing is configured (e.g. on import). The configured settings which Algolia doesn't return are kept as they are,
+// since Algolia omits some of the settings with the default values.
+func flattenIndexBundleSettings(settings search.Settings, configured string) (string, error) {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	var settingsMap map[string]interface{}
+	if err := json.Unmarshal(b, &settingsMap); err != nil {
+		return "", fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if configured == "" {
+		return string(b), nil
+	}
+
+	var configuredMap map[string]interface{}
+	if err := json.Unmarshal([]byte(configured), &configuredMap); err != nil {
+		return "", fmt.Errorf("failed to unmarshal settings_json: %w", err)
+	}
+	managed := map[string]interface{}{}
+	for key, value := range configuredMap {
+		if v, ok := settingsMap[key]; ok {
+			managed[key] = v
+		} else {
+			managed[key] = value
+		}
+	}
+	b, err = json.Marshal(managed)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	return string(b), nil
+}
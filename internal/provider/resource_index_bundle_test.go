@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceIndexBundle_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		settings = []byte(`{"attributesForFaceting":["brand"],"hitsPerPage":20,"searchableAttributes":["title"]}`)
+		synonyms = []byte(`[]`)
+		rules    = []byte(`[]`)
+	)
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			requests = append(requests, "settings")
+			body, _ := io.ReadAll(r.Body)
+			var m map[string]interface{}
+			_ = json.Unmarshal(settings, &m)
+			_ = json.Unmarshal(body, &m)
+			settings, _ = json.Marshal(m)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/batch":
+			requests = append(requests, "synonyms?replaceExistingSynonyms="+r.URL.Query().Get("replaceExistingSynonyms"))
+			synonyms, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/batch":
+			requests = append(requests, "rules?clearExistingRules="+r.URL.Query().Get("clearExistingRules"))
+			rules, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/test":
+			requests = append(requests, "delete")
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write(settings)
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/search":
+			_, _ = w.Write([]byte(`{"hits":` + string(synonyms) + `,"nbHits":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/search":
+			_, _ = w.Write([]byte(`{"hits":` + string(rules) + `,"nbHits":1,"page":0,"nbPages":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndexBundle().Schema, map[string]interface{}{
+		"name":          "test",
+		"settings_json": `{"attributesForFaceting":["brand","category"]}`,
+		"synonyms": []interface{}{
+			map[string]interface{}{"object_id": "phone", "type": "synonym", "synonyms": []interface{}{"smartphone", "mobile phone"}},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"object_id":   "brand",
+				"conditions":  []interface{}{map[string]interface{}{"pattern": "{facet:brand}", "anchoring": "contains"}},
+				"consequence": []interface{}{map[string]interface{}{"params_json": `{"automaticFacetFilters":[{"facet":"brand"}]}`}},
+			},
+		},
+		"deletion_protection": false,
+	})
+
+	if diags := resourceIndexBundleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexBundleCreate() error = %v", diags)
+	}
+	// The settings must be applied before the rules depending on them.
+	if want := []string{"settings", "synonyms?replaceExistingSynonyms=true", "rules?clearExistingRules=true"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if got := d.Id(); got != "test" {
+		t.Errorf("Id() = %v, want test", got)
+	}
+	// Only the configured settings are read.
+	if got, want := d.Get("settings_json").(string), `{"attributesForFaceting":["brand","category"]}`; got != want {
+		t.Errorf("settings_json = %v, want %v", got, want)
+	}
+	if got := d.Get("synonyms").(*schema.Set).Len(); got != 1 {
+		t.Errorf("number of synonyms = %d, want 1", got)
+	}
+	if got, want := ruleObjectIDs(d.Get("rules")), []string{"brand"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object IDs of rules = %v, want %v", got, want)
+	}
+
+	requests = nil
+	if diags := resourceIndexBundleDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexBundleDelete() error = %v", diags)
+	}
+	if want := []string{"delete"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestResourceIndexBundle_deletionProtection(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndexBundle().Schema, map[string]interface{}{"name": "test"})
+	d.SetId("test")
+
+	diags := resourceIndexBundleDelete(context.Background(), d, apiClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deletion_protection=false") {
+		t.Errorf("resourceIndexBundleDelete() error = %v, want deletion protection error", diags)
+	}
+}
+
+func Test_flattenIndexBundleSettings(t *testing.T) {
+	t.Parallel()
+
+	settings, err := unmarshalIndexBundleSettings(`{"attributesForFaceting":["brand"],"hitsPerPage":20}`)
+	if err != nil {
+		t.Fatalf("unmarshalIndexBundleSettings() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{
+			name:       "configured settings only",
+			configured: `{"hitsPerPage":10}`,
+			want:       `{"hitsPerPage":20}`,
+		},
+		{
+			name:       "settings not returned are kept",
+			configured: `{"hitsPerPage":10,"enableReRanking":true}`,
+			want:       `{"enableReRanking":true,"hitsPerPage":20}`,
+		},
+		{
+			name:       "all settings without configuration",
+			configured: "",
+			want:       `{"attributesForFaceting":["brand"],"hitsPerPage":20}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := flattenIndexBundleSettings(settings, tt.configured)
+			if err != nil {
+				t.Fatalf("flattenIndexBundleSettings() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("flattenIndexBundleSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourceIndexClear() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIndexClearCreate,
+		ReadContext:   resourceIndexClearRead,
+		DeleteContext: resourceIndexClearDelete,
+		Description: `An action to clear all records of an index without deleting the index itself. It's useful to reset data of test environments.
+
+The records are cleared when the resource is created, or re-created by changing ` + "`triggers`" + `.
+Destroying the resource only removes it from the state, and the cleared records are **not** restored.
+`,
+		// https://www.algolia.com/doc/api-reference/api-methods/clear-objects/
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index to clear records.",
+			},
+			"allow_data_loss": {
+				Type:         schema.TypeBool,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowDataLoss,
+				Description:  "Must be set to true to acknowledge that all records of the index are deleted. It prevents clearing the index by accident.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will clear the index again.",
+			},
+		},
+	}
+}
+
+func resourceIndexClearCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("index_name").(string)
+	res, err := apiClient.searchClient.InitIndex(indexName).ClearObjects(ctx)
+	if err != nil {
+		return diag.Errorf("failed to clear records of index (%s): %v", indexName, err)
+	}
+	if err := res.Wait(); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	d.SetId(indexName)
+
+	return resourceIndexClearRead(ctx, d, m)
+}
+
+func resourceIndexClearRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	exists, err := apiClient.searchClient.InitIndex(d.Id()).Exists()
+	if err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("index (%s) not found, removing from state", d.Id()))
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceIndexClearDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Cleared records can't be restored, so just remove the resource from the state.
+	return nil
+}
+
+func validateAllowDataLoss(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(bool)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be bool", k)}
+	}
+	if !v {
+		return nil, []error{errors.New("`allow_data_loss` must be true to clear all records of the index")}
+	}
+	return nil, nil
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func TestAccResourceIndexClear(t *testing.T) {
+	indexName := randResourceID(100)
+	resourceName := fmt.Sprintf("algolia_index_clear.%s", indexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexWithSeedObjects(indexName, `[{"objectID":"1","title":"foo"}]`),
+				Check:  testAccCheckIndexObjectExists(indexName, "1"),
+			},
+			{
+				Config: testAccResourceIndexClear(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index_name", indexName),
+					testAccCheckIndexObjectNotExists(indexName, "1"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckIndexDestroy,
+	})
+}
+
+func TestResourceIndexClear_allowDataLoss(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name":      "test",
+		"allow_data_loss": false,
+	}
+	diags := resourceIndexClear().Validate(terraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() || !regexp.MustCompile("`allow_data_loss` must be true").MatchString(fmt.Sprintf("%v", diags)) {
+		t.Errorf("Validate() error = %v, want allow_data_loss error", diags)
+	}
+}
+
+func TestResourceIndexClear_create(t *testing.T) {
+	t.Parallel()
+
+	cleared := false
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/clear":
+			cleared = true
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndexClear().Schema, map[string]interface{}{
+		"index_name":      "test",
+		"allow_data_loss": true,
+	})
+	if diags := resourceIndexClearCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexClearCreate() error = %v", diags)
+	}
+	if !cleared {
+		t.Error("index is not cleared")
+	}
+	if d.Id() != "test" {
+		t.Errorf("id = %v, want %v", d.Id(), "test")
+	}
+}
+
+func testAccResourceIndexClear(indexName string) string {
+	return testAccResourceIndexWithSeedObjects(indexName, `[{"objectID":"1","title":"foo"}]`) + `
+resource "algolia_index_clear" "` + indexName + `" {
+  index_name      = algolia_index.` + indexName + `.name
+  allow_data_loss = true
+}
+`
+}
+
+func testAccCheckIndexObjectNotExists(indexName string, objectID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var object map[string]interface{}
+		err := newTestAPIClient().searchClient.InitIndex(indexName).GetObject(objectID, &object)
+		if err == nil {
+			return fmt.Errorf("object '%s' still exists in index '%s'", objectID, indexName)
+		}
+		if !algoliautil.IsNotFoundError(err) {
+			return err
+		}
+		return nil
+	}
+}
@@ -1,11 +1,28 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/personalization"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -34,6 +51,7 @@ func TestAccResourceIndex(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "highlight_and_snippet_config.0.highlight_post_tag", "</em>"),
 					resource.TestCheckResourceAttr(resourceName, "highlight_and_snippet_config.0.snippet_ellipsis_text", "…"),
 					resource.TestCheckResourceAttr(resourceName, "highlight_and_snippet_config.0.restrict_highlight_and_snippet_arrays", "false"),
+					resource.TestCheckResourceAttr(resourceName, "mode", "keywordSearch"),
 					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
 				),
 			},
@@ -49,12 +67,23 @@ func TestAccResourceIndex(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", indexName),
 					resource.TestCheckResourceAttr(resourceName, "virtual", "false"),
 					testCheckResourceListAttr(resourceName, "attributes_config.0.searchable_attributes", []string{"title", "category,tag", "unordered(description)"}),
-					testCheckResourceListAttr(resourceName, "attributes_config.0.attributes_for_faceting", []string{"category"}),
+					resource.TestCheckResourceAttr(resourceName, "attributes_config.0.attributes_for_faceting.#", "4"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "attributes_config.0.attributes_for_faceting.*", "category"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "attributes_config.0.attributes_for_faceting.*", "searchable(brand)"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "attributes_config.0.attributes_for_faceting.*", "filterOnly(price)"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "attributes_config.0.attributes_for_faceting.*", "afterDistinct(color)"),
 					testCheckResourceListAttr(resourceName, "attributes_config.0.unretrievable_attributes", []string{"author_email"}),
 					testCheckResourceListAttr(resourceName, "attributes_config.0.attributes_to_retrieve", []string{"body", "category", "description", "tag", "title"}),
 					testCheckResourceListAttr(resourceName, "ranking_config.0.ranking", []string{"words", "proximity"}),
 					resource.TestCheckResourceAttr(resourceName, "faceting_config.0.max_values_per_facet", "50"),
 					resource.TestCheckResourceAttr(resourceName, "faceting_config.0.sort_facet_values_by", "alpha"),
+					testCheckResourceListAttr(resourceName, "rendering_config.0.facet_ordering.0.facets.0.order", []string{"brand", "category"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rendering_config.0.facet_ordering.0.values.*", map[string]string{
+						"facet":             "brand",
+						"order.#":           "1",
+						"order.0":           "Samsung",
+						"sort_remaining_by": "alpha",
+					}),
 					testCheckResourceListAttr(resourceName, "highlight_and_snippet_config.0.attributes_to_highlight", []string{"title"}),
 					testCheckResourceListAttr(resourceName, "highlight_and_snippet_config.0.attributes_to_snippet", []string{"description:100"}),
 					resource.TestCheckResourceAttr(resourceName, "highlight_and_snippet_config.0.highlight_pre_tag", "<b>"),
@@ -109,6 +138,58 @@ func TestAccResourceIndexWithReplica(t *testing.T) {
 	})
 }
 
+func TestAccResourceIndexWithFacetingReplica(t *testing.T) {
+	primaryIndexName := randResourceID(80)
+	replicaIndexName := fmt.Sprintf("%s_replica", primaryIndexName)
+	primaryIndexResourceName := fmt.Sprintf("algolia_index.%s", primaryIndexName)
+	replicaIndexResourceName := fmt.Sprintf("algolia_index.%s", replicaIndexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexWithFacetingReplica(primaryIndexName, replicaIndexName),
+				Check: resource.ComposeTestCheckFunc(
+					// primary index
+					testCheckResourceListAttr(primaryIndexResourceName, "attributes_config.0.attributes_for_faceting", []string{"category"}),
+					// replica index
+					resource.TestCheckResourceAttr(replicaIndexResourceName, "primary_index_name", primaryIndexName),
+					testCheckResourceListAttr(replicaIndexResourceName, "attributes_config.0.attributes_for_faceting", []string{"category"}),
+					testCheckResourceListAttr(replicaIndexResourceName, "ranking_config.0.ranking", []string{"desc(price)", "typo", "words"}),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckIndexDestroy,
+	})
+}
+
+func TestAccResourceIndexWithSeedObjects(t *testing.T) {
+	indexName := randResourceID(100)
+	resourceName := fmt.Sprintf("algolia_index.%s", indexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexWithSeedObjects(indexName, `[{"objectID":"1","title":"foo"},{"objectID":"2","title":"bar"}]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", indexName),
+					testAccCheckIndexObjectExists(indexName, "1"),
+					testAccCheckIndexObjectExists(indexName, "2"),
+				),
+			},
+			{
+				// changes to seed objects must not be reconciled
+				Config:   testAccResourceIndexWithSeedObjects(indexName, `[{"objectID":"3","title":"baz"}]`),
+				PlanOnly: true,
+			},
+		},
+		CheckDestroy: testAccCheckIndexDestroy,
+	})
+}
+
 func testAccResourceIndex(name string) string {
 	return fmt.Sprintf(`
 resource "algolia_index" "%s" {
@@ -129,7 +210,10 @@ resource "algolia_index" "` + name + `" {
       "unordered(description)",
     ]
     attributes_for_faceting = [
-      "category"
+      "category",
+      "searchable(brand)",
+      "filterOnly(price)",
+      "afterDistinct(color)",
     ]
     unretrievable_attributes = [
       "author_email"
@@ -155,6 +239,19 @@ resource "algolia_index" "` + name + `" {
     sort_facet_values_by = "alpha"
   }
 
+  rendering_config {
+    facet_ordering {
+      facets {
+        order = ["brand", "category"]
+      }
+      values {
+        facet             = "brand"
+        order             = ["Samsung"]
+        sort_remaining_by = "alpha"
+      }
+    }
+  }
+
   highlight_and_snippet_config {
     attributes_to_highlight = ["title"]
     attributes_to_snippet = ["description:100"]
@@ -187,22 +284,2650 @@ resource "algolia_index" "` + name + `" {
 `
 }
 
-// nolint:unused
-func testAccResourceIndexWithReplica(name string, replicaName string) string {
+func Test_normalizeFacetAttribute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		attribute string
+		want      string
+	}{
+		{
+			name:      "plain attribute",
+			attribute: "category",
+			want:      "category",
+		},
+		{
+			name:      "searchable",
+			attribute: "searchable(brand)",
+			want:      "searchable(brand)",
+		},
+		{
+			name:      "filterOnly",
+			attribute: "filterOnly(price)",
+			want:      "filterOnly(price)",
+		},
+		{
+			name:      "afterDistinct",
+			attribute: "afterDistinct(color)",
+			want:      "afterDistinct(color)",
+		},
+		{
+			name:      "nested modifiers",
+			attribute: "afterDistinct(searchable(color))",
+			want:      "afterDistinct(searchable(color))",
+		},
+		{
+			name:      "modifier in different case with spaces",
+			attribute: " filteronly( price ) ",
+			want:      "filterOnly(price)",
+		},
+		{
+			name:      "unknown modifier",
+			attribute: "unknown(price)",
+			want:      "unknown(price)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeFacetAttribute(tt.attribute)
+			if got != tt.want {
+				t.Errorf("normalizeFacetAttribute() = %v, want %v", got, tt.want)
+			}
+			_, errs := validateFacetAttribute(tt.attribute, "attributes_for_faceting")
+			if wantErr := got != tt.attribute; (len(errs) > 0) != wantErr {
+				t.Errorf("validateFacetAttribute() errors = %v, wantErr %v", errs, wantErr)
+			}
+		})
+	}
+}
+
+func testAccResourceIndexWithSeedObjects(name string, seedObjectsJSON string) string {
 	return `
 resource "algolia_index" "` + name + `" {
-  name = "` + name + `"
+  name              = "` + name + `"
+  seed_objects_json = jsonencode(` + seedObjectsJSON + `)
 
   deletion_protection = false
 }
+`
+}
 
-resource "algolia_index" "` + replicaName + `" {
-  name               =  "` + replicaName + `"
-  primary_index_name = algolia_index.` + name + `.name
+func TestResourceIndex_validateVirtualIndexHasPrimary(t *testing.T) {
+	t.Parallel()
 
-  deletion_protection = false
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "virtual index with primary index",
+			raw:  map[string]interface{}{"name": "test", "virtual": true, "primary_index_name": "primary"},
+		},
+		{
+			name:    "virtual index without primary index",
+			raw:     map[string]interface{}{"name": "test", "virtual": true},
+			wantErr: true,
+		},
+		{
+			name: "non virtual index without primary index",
+			raw:  map[string]interface{}{"name": "test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testResourceDiff(resourceIndex(), tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
 }
-`
+
+func Test_findDuplicatedSearchableAttribute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		searchableAttributes []string
+		wantErr              bool
+	}{
+		{
+			name:                 "no duplicates",
+			searchableAttributes: []string{"title", "category,tag", "unordered(description)"},
+		},
+		{
+			name:                 "same attribute",
+			searchableAttributes: []string{"title", "description", "title"},
+			wantErr:              true,
+		},
+		{
+			name:                 "same attribute with unordered modifier",
+			searchableAttributes: []string{"description", "unordered(description)"},
+			wantErr:              true,
+		},
+		{
+			name:                 "same attribute in the same priority",
+			searchableAttributes: []string{"title", "category,title"},
+			wantErr:              true,
+		},
+		{
+			name:                 "nested attribute is not duplicated with its parent",
+			searchableAttributes: []string{"author", "author.name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := findDuplicatedSearchableAttribute(tt.searchableAttributes); (err != nil) != tt.wantErr {
+				t.Errorf("findDuplicatedSearchableAttribute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_validateSearchableAttributesNotDuplicated(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"attributes_config": []interface{}{map[string]interface{}{
+			"searchable_attributes": []interface{}{"title", "unordered(title)"},
+		}},
+	}
+	_, err := testResourceDiff(resourceIndex(), raw)
+	if err == nil || !regexp.MustCompile("attribute 'title' is duplicated").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want duplicated attribute error", err)
+	}
+}
+
+func Test_findDuplicatedRankingCriterion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ranking []string
+		wantErr bool
+	}{
+		{
+			name:    "no duplicates",
+			ranking: []string{"desc(price)", "typo", "words", "proximity", "custom"},
+		},
+		{
+			name:    "same criterion",
+			ranking: []string{"typo", "words", "proximity", "words"},
+			wantErr: true,
+		},
+		{
+			name:    "same sort criterion",
+			ranking: []string{"asc(price)", "typo", "asc(price)"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := findDuplicatedRankingCriterion(tt.ranking); (err != nil) != tt.wantErr {
+				t.Errorf("findDuplicatedRankingCriterion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_validateRankingNotDuplicated(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"ranking_config": []interface{}{map[string]interface{}{
+			"ranking": []interface{}{"typo", "words", "words"},
+		}},
+	}
+	_, err := testResourceDiff(resourceIndex(), raw)
+	if err == nil || !regexp.MustCompile("ranking criterion 'words' is duplicated").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want duplicated ranking criterion error", err)
+	}
+}
+
+func TestResourceIndex_refreshIndexStateWhenPrimaryIsDeleted(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		// The replica is detached from the deleted primary index, so `primary` is not returned.
+		_, _ = w.Write([]byte(`{"hitsPerPage":20}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":               "replica",
+		"primary_index_name": "primary",
+	})
+	d.SetId("replica")
+
+	if err := refreshIndexState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshIndexState() error = %v", err)
+	}
+	if d.Id() != "replica" {
+		t.Errorf("id = %v, want %v", d.Id(), "replica")
+	}
+	if got := d.Get("primary_index_name").(string); got != "" {
+		t.Errorf("primary_index_name = %v, want empty", got)
+	}
+}
+
+func TestResourceIndex_deleteWhenPrimaryIsDeleted(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/primary/settings":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/replica":
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/replica/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                "replica",
+		"primary_index_name":  "primary",
+		"deletion_protection": false,
+	})
+	d.SetId("replica")
+
+	if diags := resourceIndexDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Errorf("resourceIndexDelete() error = %v", diags)
+	}
+}
+
+func Test_splitSettingsForReplicas(t *testing.T) {
+	t.Parallel()
+
+	settings := search.Settings{
+		CustomRanking: opt.CustomRanking("desc(popularity)"),
+		HitsPerPage:   opt.HitsPerPage(30),
+	}
+
+	tests := []struct {
+		name                     string
+		ignoredSettingsOnReplica []string
+		wantForwarded            search.Settings
+		wantNotForwarded         *search.Settings
+	}{
+		{
+			name:          "no ignored settings",
+			wantForwarded: settings,
+		},
+		{
+			name:                     "ignored settings are not set",
+			ignoredSettingsOnReplica: []string{"ranking"},
+			wantForwarded:            settings,
+		},
+		{
+			name:                     "ignored settings are set",
+			ignoredSettingsOnReplica: []string{"customRanking"},
+			wantForwarded:            search.Settings{HitsPerPage: opt.HitsPerPage(30)},
+			wantNotForwarded:         &search.Settings{CustomRanking: opt.CustomRanking("desc(popularity)")},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			forwarded, notForwarded, err := splitSettingsForReplicas(settings, tt.ignoredSettingsOnReplica)
+			if err != nil {
+				t.Fatalf("splitSettingsForReplicas() error = %v", err)
+			}
+			if !forwarded.Equal(tt.wantForwarded) {
+				t.Errorf("splitSettingsForReplicas() forwarded = %v, want %v", forwarded, tt.wantForwarded)
+			}
+			if (notForwarded == nil) != (tt.wantNotForwarded == nil) || (notForwarded != nil && !notForwarded.Equal(*tt.wantNotForwarded)) {
+				t.Errorf("splitSettingsForReplicas() notForwarded = %v, want %v", notForwarded, tt.wantNotForwarded)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_setIndexSettingsWithIgnoredSettingsOnReplica(t *testing.T) {
+	t.Parallel()
+
+	var forwardedBody, notForwardedBody map[string]interface{}
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			if r.URL.Query().Get("forwardToReplicas") == "true" {
+				forwardedBody = body
+			} else {
+				notForwardedBody = body
+			}
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	settings := search.Settings{
+		CustomRanking: opt.CustomRanking("desc(popularity)"),
+		HitsPerPage:   opt.HitsPerPage(30),
+	}
+	if err := setIndexSettings(apiClient.searchClient.InitIndex("test"), settings, true, []string{"customRanking"}, true); err != nil {
+		t.Fatalf("setIndexSettings() error = %v", err)
+	}
+
+	if _, ok := forwardedBody["customRanking"]; ok {
+		t.Errorf("customRanking must not be forwarded to replicas: %v", forwardedBody)
+	}
+	if _, ok := forwardedBody["hitsPerPage"]; !ok {
+		t.Errorf("hitsPerPage must be forwarded to replicas: %v", forwardedBody)
+	}
+	if _, ok := notForwardedBody["customRanking"]; !ok {
+		t.Errorf("customRanking must be applied to the index: %v", notForwardedBody)
+	}
+	if _, ok := notForwardedBody["hitsPerPage"]; ok {
+		t.Errorf("hitsPerPage must not be sent twice: %v", notForwardedBody)
+	}
+}
+
+func TestResourceIndex_updateReplicaWaitsForPrimary(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var replicaSettingsUpdatedAt time.Time
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/waiting_replica/settings":
+			mu.Lock()
+			replicaSettingsUpdatedAt = time.Now()
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/waiting_replica/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/waiting_replica/settings":
+			_, _ = w.Write([]byte(`{"primary":"waiting_primary"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":               "waiting_replica",
+		"primary_index_name": "waiting_primary",
+	})
+	d.SetId("waiting_replica")
+
+	// simulate the primary index whose settings are being applied.
+	ctx := context.Background()
+	mutexKV.Lock(ctx, algoliaIndexMutexKey(apiClient.appID, "waiting_primary"))
+	var primarySettingsUpdatedAt time.Time
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		primarySettingsUpdatedAt = time.Now()
+		mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, "waiting_primary"))
+	}()
+
+	if diags := resourceIndexUpdate(ctx, d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexUpdate() error = %v", diags)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if replicaSettingsUpdatedAt.Before(primarySettingsUpdatedAt) {
+		t.Errorf("replica settings are updated at %v before the primary settings at %v", replicaSettingsUpdatedAt, primarySettingsUpdatedAt)
+	}
+}
+
+func TestResourceIndex_updateRetriesTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var setSettingsAttempts int
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/transient/settings":
+			mu.Lock()
+			setSettingsAttempts++
+			attempts := setSettingsAttempts
+			mu.Unlock()
+			// all the 4 hosts fail on the first attempt of the write.
+			if attempts <= 4 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/transient/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/transient/settings":
+			_, _ = w.Write([]byte(`{"hitsPerPage":30}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{"name": "transient"})
+	d.SetId("transient")
+
+	if diags := resourceIndexUpdate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexUpdate() error = %v", diags)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if setSettingsAttempts != 5 {
+		t.Errorf("set settings attempts = %v, want %v", setSettingsAttempts, 5)
+	}
+}
+
+func Test_findRulesUsingFacetFilters(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/search":
+			_, _ = w.Write([]byte(`{
+  "hits": [
+    {"objectID": "automatic", "consequence": {"params": {"automaticFacetFilters": [{"facet": "brand"}]}}},
+    {"objectID": "optional", "consequence": {"params": {"automaticOptionalFacetFilters": [{"facet": "brand"}]}}},
+    {"objectID": "filters", "consequence": {"params": {"facetFilters": ["brand:apple"]}}},
+    {"objectID": "query", "consequence": {"params": {"query": "phone"}}},
+    {"objectID": "promote", "consequence": {"promote": [{"objectID": "1", "position": 0}]}}
+  ],
+  "nbHits": 5,
+  "page": 0,
+  "nbPages": 1
+}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	got, err := findRulesUsingFacetFilters(context.Background(), apiClient.searchClient.InitIndex("test"))
+	if err != nil {
+		t.Fatalf("findRulesUsingFacetFilters() error = %v", err)
+	}
+	if want := []string{"automatic", "optional", "filters"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findRulesUsingFacetFilters() got = %v, want %v", got, want)
+	}
+}
+
+func TestResourceIndex_warnFacetFiltersWithoutAttributesForFaceting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		state     *terraform.InstanceState
+		wantRules bool
+	}{
+		{
+			name:      "new index",
+			state:     nil,
+			wantRules: false,
+		},
+		{
+			name: "attributes for faceting removed",
+			state: &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+				"name":                "test",
+				"attributes_config.#": "1",
+				"attributes_config.0.attributes_for_faceting.#":                                           "1",
+				fmt.Sprintf("attributes_config.0.attributes_for_faceting.%d", schema.HashString("brand")): "brand",
+			}},
+			wantRules: true,
+		},
+		{
+			name: "attributes for faceting unchanged",
+			state: &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+				"name":                "test",
+				"attributes_config.#": "1",
+				"attributes_config.0.attributes_for_faceting.#": "0",
+			}},
+			wantRules: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var rulesRequested bool
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/search":
+					rulesRequested = true
+					_, _ = w.Write([]byte(`{"hits":[{"objectID":"brand","consequence":{"params":{"automaticFacetFilters":[{"facet":"brand"}]}}}],"nbHits":1,"page":0,"nbPages":1}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"attributes_config": []interface{}{map[string]interface{}{
+					"attributes_for_faceting": []interface{}{},
+				}},
+			}
+			logs := testResourceDiffLogs(t, resourceIndex(), tt.state, raw, apiClient)
+			// The rules are browsed only when `attributes_for_faceting` is changed, not on every plan.
+			if rulesRequested != tt.wantRules {
+				t.Errorf("rules requested = %v, want %v", rulesRequested, tt.wantRules)
+			}
+			if got := strings.Contains(logs, "rules (brand) of index (test) use facet filters"); got != tt.wantRules {
+				t.Errorf("warned = %v, want %v, logs: %q", got, tt.wantRules, logs)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnRuleFacetsNotInAttributesForFaceting(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/search":
+			_, _ = w.Write([]byte(`{
+  "hits": [
+    {"objectID": "brand", "conditions": [{"pattern": "{facet:brand}", "anchoring": "contains"}], "consequence": {"params": {"automaticFacetFilters": [{"facet": "brand"}]}}},
+    {"objectID": "color", "conditions": [{"pattern": "{facet:color} shoes", "anchoring": "contains"}], "consequence": {"params": {"automaticFacetFilters": [{"facet": "color"}]}}},
+    {"objectID": "category", "conditions": [{"pattern": "{facet:category.lvl0}", "anchoring": "is"}], "consequence": {"params": {"automaticFacetFilters": [{"facet": "category.lvl0"}]}}}
+  ],
+  "nbHits": 3,
+  "page": 0,
+  "nbPages": 1
+}`))
+		default:
+			// the other checks of the plan are best-effort.
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found","status":404}`))
+		}
+	})
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"attributes_config": []interface{}{map[string]interface{}{
+			"attributes_for_faceting": []interface{}{"searchable(brand)", "filterOnly(category)"},
+		}},
+	}
+	state := &terraform.InstanceState{ID: "test", Attributes: map[string]string{"name": "test"}}
+	logs := testResourceDiffLogs(t, resourceIndex(), state, raw, apiClient)
+	if want := "rule (color) of index (test) uses `{facet:color}` in its condition"; !strings.Contains(logs, want) {
+		t.Errorf("expected %q in logs, got %q", want, logs)
+	}
+	for _, ruleID := range []string{"brand", "category"} {
+		if unwanted := fmt.Sprintf("rule (%s) of index (test) uses", ruleID); strings.Contains(logs, unwanted) {
+			t.Errorf("unexpected %q in logs, got %q", unwanted, logs)
+		}
+	}
+}
+
+func Test_resolveWaitForTask(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled := true, false
+	tests := []struct {
+		name            string
+		waitForTask     *bool
+		providerDefault bool
+		want            bool
+	}{
+		{
+			name:            "provider default is used when not specified",
+			providerDefault: false,
+			want:            false,
+		},
+		{
+			name:            "resource overrides provider default to false",
+			waitForTask:     &disabled,
+			providerDefault: true,
+			want:            false,
+		},
+		{
+			name:            "resource overrides provider default to true",
+			waitForTask:     &enabled,
+			providerDefault: false,
+			want:            true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resolveWaitForTask(tt.waitForTask, tt.providerDefault); got != tt.want {
+				t.Errorf("resolveWaitForTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeSearchIndex is a searchIndex which records the settings set to it.
+type fakeSearchIndex struct {
+	searchIndex
+	settings []search.Settings
+}
+
+func (i *fakeSearchIndex) SetSettings(settings search.Settings, opts ...interface{}) (search.UpdateTaskRes, error) {
+	i.settings = append(i.settings, settings)
+	// the returned task can't be waited for, so that waiting for it fails the test.
+	return search.UpdateTaskRes{}, nil
+}
+
+func TestResourceIndex_setIndexSettingsWithoutWaitingForTask(t *testing.T) {
+	t.Parallel()
+
+	index := &fakeSearchIndex{}
+	settings := search.Settings{HitsPerPage: opt.HitsPerPage(30)}
+	if err := setIndexSettings(index, settings, false, nil, false); err != nil {
+		t.Fatalf("setIndexSettings() error = %v", err)
+	}
+	if len(index.settings) != 1 || index.settings[0].HitsPerPage.Get() != 30 {
+		t.Errorf("setIndexSettings() set %v, want hitsPerPage 30", index.settings)
+	}
+}
+
+func TestResourceIndex_importReplica(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		primaryReplicas string
+		wantVirtual     bool
+	}{
+		{
+			name:            "standard replica",
+			primaryReplicas: `["replica"]`,
+			wantVirtual:     false,
+		},
+		{
+			name:            "virtual replica",
+			primaryReplicas: `["virtual(replica)"]`,
+			wantVirtual:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/replica/settings":
+					_, _ = w.Write([]byte(`{"primary":"primary"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/primary/settings":
+					_, _ = w.Write([]byte(`{"replicas":` + tt.primaryReplicas + `}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{})
+			d.SetId("replica")
+
+			if _, err := resourceIndexStateContext(context.Background(), d, apiClient); err != nil {
+				t.Fatalf("resourceIndexStateContext() error = %v", err)
+			}
+			if got := d.Get("primary_index_name").(string); got != "primary" {
+				t.Errorf("primary_index_name = %v, want %v", got, "primary")
+			}
+			if got := d.Get("virtual").(bool); got != tt.wantVirtual {
+				t.Errorf("virtual = %v, want %v", got, tt.wantVirtual)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_importWithoutDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings string
+	}{
+		{
+			name:     "new index",
+			settings: `{"minWordSizefor1Typo":4,"minWordSizefor2Typos":8,"hitsPerPage":20,"maxValuesPerFacet":100,"version":2,"searchableAttributes":null,"attributesToRetrieve":null,"ignorePlurals":false,"removeStopWords":false,"numericAttributesToIndex":null,"attributesToHighlight":null,"paginationLimitedTo":1000,"attributeForDistinct":null,"exactOnSingleWordQuery":"attribute","ranking":["typo","geo","words","filters","proximity","attribute","exact","custom"],"customRanking":null,"separatorsToIndex":"","removeWordsIfNoResults":"none","queryType":"prefixLast","highlightPreTag":"<em>","highlightPostTag":"</em>","alternativesAsExact":["ignorePlurals","singleWordSynonym"]}`,
+		},
+		{
+			name:     "no settings returned",
+			settings: `{}`,
+		},
+		{
+			name:     "customized index",
+			settings: `{"searchableAttributes":["title","unordered(body)"],"attributesForFaceting":["brand"],"customRanking":["desc(popularity)"],"typoTolerance":"strict","ignorePlurals":["en"],"queryLanguages":["en"],"distinct":1,"attributeForDistinct":"sku","hitsPerPage":50}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+					_, _ = w.Write([]byte(tt.settings))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := resourceIndex().Data(nil)
+			d.SetId("test")
+			if _, err := resourceIndexStateContext(context.Background(), d, apiClient); err != nil {
+				t.Fatalf("resourceIndexStateContext() error = %v", err)
+			}
+
+			// The plan right after the import must be empty, including the fields which aren't stored in Algolia.
+			diff, err := resourceIndex().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{"name": "test"}), apiClient)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if diff != nil && !diff.Empty() {
+				t.Errorf("Diff() = %v, want empty", diff)
+			}
+		})
+	}
+}
+
+func Test_findFacetsWithDifferentSort(t *testing.T) {
+	t.Parallel()
+
+	alpha, count, hidden := search.Alpha, search.Count, search.Hidden
+	renderingContent := &search.RenderingContent{
+		FacetOrdering: &search.FacetOrdering{
+			Values: map[string]search.FacetValuesOrder{
+				"brand":    {SortRemainingBy: &alpha},
+				"color":    {SortRemainingBy: &count},
+				"size":     {SortRemainingBy: &hidden},
+				"category": {Order: []string{"shoes"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		sortFacetValuesBy string
+		renderingContent  *search.RenderingContent
+		want              []string
+	}{
+		{
+			name:              "no rendering content",
+			sortFacetValuesBy: "count",
+			want:              nil,
+		},
+		{
+			name:              "sorted by count",
+			sortFacetValuesBy: "count",
+			renderingContent:  renderingContent,
+			want:              []string{"brand"},
+		},
+		{
+			name:              "sorted by alpha",
+			sortFacetValuesBy: "alpha",
+			renderingContent:  renderingContent,
+			want:              []string{"color"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := findFacetsWithDifferentSort(tt.sortFacetValuesBy, tt.renderingContent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findFacetsWithDifferentSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnInconsistentFacetValuesSort(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"renderingContent":{"facetOrdering":{"values":{"brand":{"sortRemainingBy":"alpha"}}}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"faceting_config": []interface{}{map[string]interface{}{
+			"sort_facet_values_by": "count",
+		}},
+	}
+	state := &terraform.InstanceState{ID: "test", Attributes: map[string]string{"name": "test"}}
+	logs := testResourceDiffLogs(t, resourceIndex(), state, raw, apiClient)
+	if want := "facets (brand) of index (test) are sorted differently from `sort_facet_values_by` (count)"; !strings.Contains(logs, want) {
+		t.Errorf("expected %q in logs, got %q", want, logs)
+	}
+}
+
+func TestResourceIndex_warnInconsistentFacetValuesSortOnlyWhenChanged(t *testing.T) {
+	t.Parallel()
+
+	// the settings must not be fetched when the sorts are unchanged.
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"faceting_config": []interface{}{map[string]interface{}{
+			"sort_facet_values_by": "count",
+		}},
+	}
+	state := &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+		"name":                                   "test",
+		"faceting_config.#":                      "1",
+		"faceting_config.0.sort_facet_values_by": "count",
+		"faceting_config.0.max_values_per_facet": "100",
+	}}
+	if _, err := resourceIndex().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), apiClient); err != nil {
+		t.Errorf("Diff() error = %v, want nil", err)
+	}
+}
+
+func TestResourceIndex_readUnexpectedSortFacetValuesBy(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"sortFacetValuesBy":"relevance","renderingContent":{"facetOrdering":{"values":{"brand":{"sortRemainingBy":"relevance"}}}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name": "test",
+	})
+	d.SetId("test")
+
+	// unexpected values are read as they are to surface the drift.
+	if err := refreshIndexState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshIndexState() error = %v", err)
+	}
+	if got := d.Get("faceting_config.0.sort_facet_values_by").(string); got != "relevance" {
+		t.Errorf("faceting_config.0.sort_facet_values_by = %v, want relevance", got)
+	}
+	if got := d.Get("rendering_config.0.facet_ordering.0.values").(*schema.Set).List(); len(got) != 1 || got[0].(map[string]interface{})["sort_remaining_by"] != "relevance" {
+		t.Errorf("rendering_config.0.facet_ordering.0.values = %v, want sort_remaining_by relevance", got)
+	}
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"faceting_config": []interface{}{map[string]interface{}{
+			"sort_facet_values_by": "count",
+		}},
+	}
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	diff, err := resourceIndex().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), apiClient)
+	if err != nil {
+		t.Fatalf("Diff() error = %v, want nil", err)
+	}
+	if attr, ok := diff.Attributes["faceting_config.0.sort_facet_values_by"]; !ok || attr.Old != "relevance" || attr.New != "count" {
+		t.Errorf("diff of faceting_config.0.sort_facet_values_by = %v, want relevance => count", attr)
+	}
+	if !strings.Contains(logs.String(), "`sortFacetValuesBy` of index (test) is `relevance`") {
+		t.Errorf("logs = %q, want unexpected sortFacetValuesBy warning", logs.String())
+	}
+}
+
+func TestResourceIndex_validateMinWordSizesForTypos(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		minWordSizeFor1Typo  int
+		minWordSizeFor2Typos int
+		wantErr              bool
+	}{
+		{
+			name:                 "2 typos threshold is larger",
+			minWordSizeFor1Typo:  4,
+			minWordSizeFor2Typos: 8,
+			wantErr:              false,
+		},
+		{
+			name:                 "same thresholds",
+			minWordSizeFor1Typo:  4,
+			minWordSizeFor2Typos: 4,
+			wantErr:              false,
+		},
+		{
+			name:                 "inverted thresholds",
+			minWordSizeFor1Typo:  8,
+			minWordSizeFor2Typos: 4,
+			wantErr:              true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"typos_config": []interface{}{map[string]interface{}{
+					"min_word_size_for_1_typo":  tt.minWordSizeFor1Typo,
+					"min_word_size_for_2_typos": tt.minWordSizeFor2Typos,
+				}},
+			}
+			_, err := testResourceDiff(resourceIndex(), raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !regexp.MustCompile("`min_word_size_for_1_typo` \\(8\\) must not be larger than `min_word_size_for_2_typos` \\(4\\)").MatchString(err.Error()) {
+				t.Errorf("Diff() error = %v, want inverted thresholds error", err)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnPrimaryIndexNameChange(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{"name": "replica", "primary_index_name": "new_primary"}
+	state := &terraform.InstanceState{ID: "replica", Attributes: map[string]string{"name": "replica", "primary_index_name": "old_primary"}}
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	diff, err := resourceIndex().Diff(ctx, state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("Diff() must require new resource")
+	}
+	if !strings.Contains(logs.String(), "changing `primary_index_name` of index (replica) from 'old_primary' to 'new_primary' forces the index to be deleted and recreated") {
+		t.Errorf("expected primary_index_name change warning in logs, got %q", logs.String())
+	}
+}
+
+func Test_validateTypoTolerance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{
+			name:  "valid mode",
+			value: "min",
+		},
+		{
+			name:    "number of typos",
+			value:   "1",
+			wantErr: `expected typo_tolerance to be one of \["true" "false" "min" "strict"\], got "1". typo_tolerance is not a number of typos, use ` + "`min_word_size_for_1_typo`",
+		},
+		{
+			name:    "unknown mode",
+			value:   "loose",
+			wantErr: `expected typo_tolerance to be one of \["true" "false" "min" "strict"\], got "loose"$`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, errs := validateTypoTolerance(tt.value, "typo_tolerance")
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateTypoTolerance() errors = %v, want nil", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !regexp.MustCompile(tt.wantErr).MatchString(errs[0].Error()) {
+				t.Errorf("validateTypoTolerance() errors = %v, want %s", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_findUnmanagedSettings(t *testing.T) {
+	t.Parallel()
+
+	remote := search.Settings{
+		HitsPerPage:   opt.HitsPerPage(20),
+		CustomRanking: opt.CustomRanking("desc(popularity)"),
+		Primary:       opt.Primary("primary"),
+		CustomSettings: map[string]interface{}{
+			"newSetting": true,
+		},
+	}
+	managed := search.Settings{
+		HitsPerPage: opt.HitsPerPage(30),
+	}
+
+	got, err := findUnmanagedSettings(remote, managed)
+	if err != nil {
+		t.Fatalf("findUnmanagedSettings() error = %v", err)
+	}
+	if want := []string{"customRanking", "newSetting"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findUnmanagedSettings() = %v, want %v", got, want)
+	}
+}
+
+func TestResourceIndex_refreshIndexStateWithDetectUnmanagedDrift(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hitsPerPage":20,"newSetting":true}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                   "test",
+		"detect_unmanaged_drift": true,
+		"pagination_config": []interface{}{map[string]interface{}{
+			"hits_per_page": 20,
+		}},
+	})
+	d.SetId("test")
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	if err := refreshIndexState(ctx, d, apiClient); err != nil {
+		t.Fatalf("refreshIndexState() error = %v", err)
+	}
+	if !strings.Contains(logs.String(), "index (test) has settings which are not managed by Terraform: newSetting") {
+		t.Errorf("expected unmanaged settings in logs, got %q", logs.String())
+	}
+}
+
+func Test_isPersonalizationStrategyEmpty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		strategy personalization.Strategy
+		want     bool
+	}{
+		{
+			name: "empty",
+			want: true,
+		},
+		{
+			name: "events scoring",
+			strategy: personalization.Strategy{
+				EventsScoring: []personalization.EventsScoring{{EventName: "Add to cart", EventType: "conversion", Score: 50}},
+			},
+			want: false,
+		},
+		{
+			name: "facets scoring",
+			strategy: personalization.Strategy{
+				FacetsScoring: []personalization.FacetsScoring{{FacetName: "brand", Score: 100}},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isPersonalizationStrategyEmpty(tt.strategy); got != tt.want {
+				t.Errorf("isPersonalizationStrategyEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnPersonalizationWithoutStrategy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		personalizationRegion region.Region
+		wantWarning           bool
+	}{
+		{
+			name:                  "no strategy in us",
+			personalizationRegion: region.US,
+			wantWarning:           true,
+		},
+		{
+			name:                  "strategy in eu",
+			personalizationRegion: region.EU,
+			wantWarning:           false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/strategies/personalization" && r.URL.Host == "recommendation.eu.algolia.com":
+					_, _ = w.Write([]byte(`{"eventsScoring":[{"eventName":"click","eventType":"click","score":10}],"facetsScoring":[],"personalizationImpact":50}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/strategies/personalization":
+					_, _ = w.Write([]byte(`{"eventsScoring":[],"facetsScoring":[],"personalizationImpact":0}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+			apiClient.personalizationRegion = tt.personalizationRegion
+
+			raw := map[string]interface{}{"name": "test", "enable_personalization": true}
+			logs := testResourceDiffLogs(t, resourceIndex(), nil, raw, apiClient)
+			if got := strings.Contains(logs, "no personalization strategy is configured"); got != tt.wantWarning {
+				t.Errorf("warned = %v, want %v, logs: %q", got, tt.wantWarning, logs)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnLanguageFeaturesWithoutQueryLanguages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		languagesConfig map[string]interface{}
+		wantWarning     bool
+	}{
+		{
+			name:            "ignore plurals without query languages",
+			languagesConfig: map[string]interface{}{"ignore_plurals": true},
+			wantWarning:     true,
+		},
+		{
+			name:            "remove stop words without query languages",
+			languagesConfig: map[string]interface{}{"remove_stop_words": true},
+			wantWarning:     true,
+		},
+		{
+			name: "decompounding without query languages",
+			languagesConfig: map[string]interface{}{
+				"decompounded_attributes": []interface{}{map[string]interface{}{
+					"language":   "de",
+					"attributes": []interface{}{"name"},
+				}},
+			},
+			wantWarning: true,
+		},
+		{
+			name:            "ignore plurals with query languages",
+			languagesConfig: map[string]interface{}{"ignore_plurals": true, "query_languages": []interface{}{"en"}},
+			wantWarning:     false,
+		},
+		{
+			name:            "ignore plurals for specific languages",
+			languagesConfig: map[string]interface{}{"ignore_plurals_for": []interface{}{"en"}},
+			wantWarning:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name":             "test",
+				"languages_config": []interface{}{tt.languagesConfig},
+			}
+			assertDiffWarns(t, resourceIndex(), raw, "without `query_languages`", tt.wantWarning)
+		})
+	}
+}
+
+func TestResourceIndex_warnDecompoundQueryWithoutDecompoundingLanguages(t *testing.T) {
+	t.Parallel()
+
+	decompoundedAttributes := []interface{}{map[string]interface{}{
+		"language":   "de",
+		"attributes": []interface{}{"name"},
+	}}
+	tests := []struct {
+		name            string
+		languagesConfig map[string]interface{}
+		wantWarning     bool
+	}{
+		{
+			name: "query languages without decompounding",
+			languagesConfig: map[string]interface{}{
+				"decompounded_attributes": decompoundedAttributes,
+				"query_languages":         []interface{}{"en"},
+			},
+			wantWarning: true,
+		},
+		{
+			name: "query languages with decompounding",
+			languagesConfig: map[string]interface{}{
+				"decompounded_attributes": decompoundedAttributes,
+				"query_languages":         []interface{}{"en", "de"},
+			},
+			wantWarning: false,
+		},
+		{
+			name: "index languages with decompounding",
+			languagesConfig: map[string]interface{}{
+				"decompounded_attributes": decompoundedAttributes,
+				"query_languages":         []interface{}{"en"},
+				"index_languages":         []interface{}{"nl"},
+			},
+			wantWarning: false,
+		},
+		{
+			name: "decompound query disabled",
+			languagesConfig: map[string]interface{}{
+				"decompounded_attributes": decompoundedAttributes,
+				"query_languages":         []interface{}{"en"},
+				"decompound_query":        false,
+			},
+			wantWarning: false,
+		},
+		{
+			name:            "without decompounded attributes",
+			languagesConfig: map[string]interface{}{"query_languages": []interface{}{"en"}},
+			wantWarning:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name":             "test",
+				"languages_config": []interface{}{tt.languagesConfig},
+			}
+			assertDiffWarns(t, resourceIndex(), raw, "`decompound_query` of index (test) is enabled, but none of", tt.wantWarning)
+		})
+	}
+}
+
+func TestResourceIndex_warnReplicaWithoutSortCriteria(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		wantWarning bool
+	}{
+		{
+			name:        "replica without ranking config",
+			raw:         map[string]interface{}{"name": "test_replica", "primary_index_name": "test"},
+			wantWarning: true,
+		},
+		{
+			name: "replica with custom ranking",
+			raw: map[string]interface{}{
+				"name":               "test_replica",
+				"primary_index_name": "test",
+				"ranking_config":     []interface{}{map[string]interface{}{"custom_ranking": []interface{}{"desc(price)"}}},
+			},
+			wantWarning: false,
+		},
+		{
+			name: "replica with sort criterion in ranking",
+			raw: map[string]interface{}{
+				"name":               "test_replica",
+				"primary_index_name": "test",
+				"ranking_config":     []interface{}{map[string]interface{}{"ranking": []interface{}{"asc(price)", "typo", "words"}}},
+			},
+			wantWarning: false,
+		},
+		{
+			name:        "primary index",
+			raw:         map[string]interface{}{"name": "test"},
+			wantWarning: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertDiffWarns(t, resourceIndex(), tt.raw, "has neither `custom_ranking` nor a sort criterion", tt.wantWarning)
+		})
+	}
+}
+
+func TestResourceIndex_warnUnretrievableAttributesToRetrieve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                    string
+		attributesToRetrieve    []interface{}
+		unretrievableAttributes []interface{}
+		wantWarning             bool
+	}{
+		{
+			name:                    "same attribute in both",
+			attributesToRetrieve:    []interface{}{"title", "price"},
+			unretrievableAttributes: []interface{}{"price"},
+			wantWarning:             true,
+		},
+		{
+			name:                    "retrieve all attributes",
+			attributesToRetrieve:    []interface{}{"*"},
+			unretrievableAttributes: []interface{}{"price"},
+			wantWarning:             false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"attributes_config": []interface{}{map[string]interface{}{
+					"attributes_to_retrieve":   tt.attributesToRetrieve,
+					"unretrievable_attributes": tt.unretrievableAttributes,
+				}},
+			}
+			assertDiffWarns(t, resourceIndex(), raw, "listed in both `attributes_to_retrieve` and `unretrievable_attributes`", tt.wantWarning)
+		})
+	}
+}
+
+func TestResourceIndex_warnEmptySearchableAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		wantWarning bool
+	}{
+		{
+			name: "attributes_config without searchable_attributes",
+			raw: map[string]interface{}{
+				"name": "test",
+				"attributes_config": []interface{}{map[string]interface{}{
+					"attributes_for_faceting": []interface{}{"category"},
+				}},
+			},
+			wantWarning: true,
+		},
+		{
+			name: "attributes_config with searchable_attributes",
+			raw: map[string]interface{}{
+				"name": "test",
+				"attributes_config": []interface{}{map[string]interface{}{
+					"searchable_attributes": []interface{}{"title"},
+				}},
+			},
+			wantWarning: false,
+		},
+		{
+			name:        "attributes_config is not set",
+			raw:         map[string]interface{}{"name": "test"},
+			wantWarning: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertDiffWarns(t, resourceIndex(), tt.raw, "all attributes are searchable unordered", tt.wantWarning)
+		})
+	}
+}
+
+func TestResourceIndex_warnPaginationLimitedToLowered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		state       *terraform.InstanceState
+		wantWarning string
+	}{
+		{
+			name: "lowered",
+			state: &terraform.InstanceState{ID: "test", Attributes: map[string]string{
+				"name":                              "test",
+				"pagination_config.#":               "1",
+				"pagination_config.0.hits_per_page": "20",
+				"pagination_config.0.pagination_limited_to": "1000",
+			}},
+			wantWarning: "is lowered from 1000 to 10",
+		},
+		{
+			name:        "lower than hits per page",
+			wantWarning: "is lower than `hits_per_page` (20)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"pagination_config": []interface{}{map[string]interface{}{
+					"hits_per_page":         20,
+					"pagination_limited_to": 10,
+				}},
+			}
+			if logs := testResourceDiffLogs(t, resourceIndex(), tt.state, raw, &apiClient{}); !strings.Contains(logs, tt.wantWarning) {
+				t.Errorf("expected %q in logs, got %q", tt.wantWarning, logs)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_paginationLimitedToRoundTripOnReplica(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":               "test_replica",
+		"primary_index_name": "test",
+		"pagination_config": []interface{}{map[string]interface{}{
+			"pagination_limited_to": 100,
+		}},
+	})
+	d.SetId("test_replica")
+
+	settings := mapToIndexSettings(d)
+	b, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var readSettings search.Settings
+	if err := json.Unmarshal(b, &readSettings); err != nil {
+		t.Fatal(err)
+	}
+	readSettings.Primary = opt.Primary("test")
+	if err := setValues(d, mapToIndexResourceValues(d, readSettings)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.Get("pagination_config.0.pagination_limited_to").(int); got != 100 {
+		t.Errorf("pagination_limited_to = %d, want 100", got)
+	}
+}
+
+func TestResourceIndex_renderingConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	renderingConfig := []interface{}{map[string]interface{}{
+		"facet_ordering": []interface{}{map[string]interface{}{
+			"facets": []interface{}{map[string]interface{}{
+				"order": []interface{}{"brand", "category"},
+			}},
+			"values": []interface{}{map[string]interface{}{
+				"facet":             "brand",
+				"order":             []interface{}{"Samsung", "Apple"},
+				"sort_remaining_by": "hidden",
+				"hide":              []interface{}{"Unknown"},
+			}},
+		}},
+	}}
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":             "test",
+		"rendering_config": renderingConfig,
+	})
+	d.SetId("test")
+
+	b, err := json.Marshal(mapToIndexSettings(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"renderingContent":{"facetOrdering":{"facets":{"order":["brand","category"]},"values":{"brand":{"order":["Samsung","Apple"],"sortRemainingBy":"hidden","hide":["Unknown"]}}}}`; !strings.Contains(string(b), want) {
+		t.Errorf("settings = %s, want to contain %s", b, want)
+	}
+
+	var readSettings search.Settings
+	if err := json.Unmarshal(b, &readSettings); err != nil {
+		t.Fatal(err)
+	}
+	if err := setValues(d, mapToIndexResourceValues(d, readSettings)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := castStringList(d.Get("rendering_config.0.facet_ordering.0.facets.0.order")), []string{"brand", "category"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("facets order = %v, want %v", got, want)
+	}
+	values := d.Get("rendering_config.0.facet_ordering.0.values").(*schema.Set).List()
+	if len(values) != 1 {
+		t.Fatalf("values = %v, want 1 element", values)
+	}
+	got := values[0].(map[string]interface{})
+	if got["facet"] != "brand" || got["sort_remaining_by"] != "hidden" ||
+		!reflect.DeepEqual(castStringList(got["order"]), []string{"Samsung", "Apple"}) ||
+		!reflect.DeepEqual(castStringList(got["hide"]), []string{"Unknown"}) {
+		t.Errorf("values = %v, want the configured values", got)
+	}
+}
+
+func TestResourceIndex_reRankingApplyFilterRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		virtual        bool
+		wantSettings   string
+		wantReadFilter []interface{}
+	}{
+		{
+			name:           "index",
+			wantSettings:   `"reRankingApplyFilter":[["category:Book","category:Movie"],["brand:Algolia"]]`,
+			wantReadFilter: []interface{}{[]interface{}{"category:Book", "category:Movie"}, []interface{}{"brand:Algolia"}},
+		},
+		{
+			name:    "virtual index",
+			virtual: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+				"name":               "test",
+				"primary_index_name": "primary",
+				"virtual":            tt.virtual,
+				"ranking_config": []interface{}{map[string]interface{}{
+					"re_ranking_apply_filter": []interface{}{
+						[]interface{}{"category:Book", "category:Movie"},
+						[]interface{}{"brand:Algolia"},
+					},
+				}},
+			})
+			d.SetId("test")
+
+			b, err := json.Marshal(mapToIndexSettings(d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Contains(string(b), "reRankingApplyFilter"); got != (tt.wantSettings != "") {
+				t.Fatalf("settings = %s, want reRankingApplyFilter sent = %v", b, tt.wantSettings != "")
+			}
+			if !strings.Contains(string(b), tt.wantSettings) {
+				t.Errorf("settings = %s, want to contain %s", b, tt.wantSettings)
+			}
+
+			var readSettings search.Settings
+			if err := json.Unmarshal(b, &readSettings); err != nil {
+				t.Fatal(err)
+			}
+			if err := setValues(d, mapToIndexResourceValues(d, readSettings)); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantReadFilter == nil {
+				return
+			}
+			if got := d.Get("ranking_config.0.re_ranking_apply_filter"); !reflect.DeepEqual(got, tt.wantReadFilter) {
+				t.Errorf("re_ranking_apply_filter = %v, want %v", got, tt.wantReadFilter)
+			}
+		})
+	}
+}
+
+func Test_marshalRankingConfig_reRankingApplyFilterString(t *testing.T) {
+	t.Parallel()
+
+	// Algolia also accepts the filters as a string.
+	var settings search.Settings
+	if err := json.Unmarshal([]byte(`{"reRankingApplyFilter":"brand:Algolia"}`), &settings); err != nil {
+		t.Fatal(err)
+	}
+	got := marshalRankingConfig(settings, false)[0].(map[string]interface{})["re_ranking_apply_filter"]
+	if want := [][]string{{"brand:Algolia"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("re_ranking_apply_filter = %v, want %v", got, want)
+	}
+}
+
+func TestResourceIndex_customNormalizationRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                          string
+		languagesConfig               map[string]interface{}
+		wantSettings                  string
+		wantCustomNormalization       map[string]interface{}
+		wantCustomNormalizationByLang int
+	}{
+		{
+			name:                    "default bucket as a map",
+			languagesConfig:         map[string]interface{}{"custom_normalization": map[string]interface{}{"ä": "ae"}},
+			wantSettings:            `"customNormalization":{"default":{"ä":"ae"}}`,
+			wantCustomNormalization: map[string]interface{}{"ä": "ae"},
+		},
+		{
+			name: "language buckets",
+			languagesConfig: map[string]interface{}{"custom_normalization_by_language": []interface{}{
+				map[string]interface{}{"language": "default", "normalization": map[string]interface{}{"ß": "ss"}},
+				map[string]interface{}{"language": "de", "normalization": map[string]interface{}{"ä": "ae"}},
+			}},
+			wantSettings:                  `"customNormalization":{"de":{"ä":"ae"},"default":{"ß":"ss"}}`,
+			wantCustomNormalization:       map[string]interface{}{},
+			wantCustomNormalizationByLang: 2,
+		},
+		{
+			name: "default bucket only",
+			languagesConfig: map[string]interface{}{"custom_normalization_by_language": []interface{}{
+				map[string]interface{}{"language": "default", "normalization": map[string]interface{}{"ß": "ss"}},
+			}},
+			wantSettings:                  `"customNormalization":{"default":{"ß":"ss"}}`,
+			wantCustomNormalization:       map[string]interface{}{},
+			wantCustomNormalizationByLang: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+				"name":             "test",
+				"languages_config": []interface{}{tt.languagesConfig},
+			})
+			d.SetId("test")
+
+			b, err := json.Marshal(mapToIndexSettings(d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tt.wantSettings) {
+				t.Errorf("settings = %s, want to contain %s", b, tt.wantSettings)
+			}
+
+			var readSettings search.Settings
+			if err := json.Unmarshal(b, &readSettings); err != nil {
+				t.Fatal(err)
+			}
+			if err := setValues(d, mapToIndexResourceValues(d, readSettings)); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Get("languages_config.0.custom_normalization"); !reflect.DeepEqual(got, tt.wantCustomNormalization) {
+				t.Errorf("custom_normalization = %v, want %v", got, tt.wantCustomNormalization)
+			}
+			if got := d.Get("languages_config.0.custom_normalization_by_language").(*schema.Set).Len(); got != tt.wantCustomNormalizationByLang {
+				t.Errorf("number of custom_normalization_by_language = %d, want %d", got, tt.wantCustomNormalizationByLang)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_readCustomNormalizationWithLanguageBuckets(t *testing.T) {
+	t.Parallel()
+
+	var settings search.Settings
+	if err := json.Unmarshal([]byte(`{"customNormalization":{"default":{"ß":"ss"},"de":{"ä":"ae"}}}`), &settings); err != nil {
+		t.Fatal(err)
+	}
+	// The language buckets set outside Terraform are read even if `custom_normalization` is used.
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name": "test",
+		"languages_config": []interface{}{map[string]interface{}{
+			"custom_normalization": map[string]interface{}{"ß": "ss"},
+		}},
+	})
+	d.SetId("test")
+	if err := setValues(d, mapToIndexResourceValues(d, settings)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.Get("languages_config.0.custom_normalization").(map[string]interface{}); len(got) != 0 {
+		t.Errorf("custom_normalization = %v, want empty", got)
+	}
+	want := map[string]interface{}{"language": "de", "normalization": map[string]interface{}{"ä": "ae"}}
+	if got := d.Get("languages_config.0.custom_normalization_by_language").(*schema.Set); got.Len() != 2 || !got.Contains(want) {
+		t.Errorf("custom_normalization_by_language = %v, want to contain %v", got.List(), want)
+	}
+}
+
+func TestResourceIndex_createWithFailedSeedObjects(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/batch":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Record is too big","status":400}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                "test",
+		"seed_objects_json":   `[{"objectID":"1","title":"foo"}]`,
+		"deletion_protection": false,
+	})
+	if diags := resourceIndexCreate(context.Background(), d, apiClient); !diags.HasError() {
+		t.Fatalf("resourceIndexCreate() error = nil, want seed objects error")
+	}
+	// The ID is kept so that the created index is tracked in state instead of being orphaned.
+	if got := d.Id(); got != "test" {
+		t.Errorf("Id() = %q, want %q", got, "test")
+	}
+}
+
+func TestResourceIndex_decompoundedAttributesWithoutDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		settings = []byte(`{}`)
+	)
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			settings, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write(settings)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	raw := map[string]interface{}{
+		"name":                "test",
+		"deletion_protection": false,
+		"languages_config": []interface{}{map[string]interface{}{
+			"decompounded_attributes": []interface{}{
+				map[string]interface{}{"language": "de", "attributes": []interface{}{"title"}},
+				map[string]interface{}{"language": "nl", "attributes": []interface{}{"body"}},
+			},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, raw)
+	if diags := resourceIndexCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexCreate() error = %v", diags)
+	}
+
+	// The decompounded attributes are returned as a map, so refresh several times to cover its random iteration order.
+	for i := 0; i < 10; i++ {
+		if err := refreshIndexState(context.Background(), d, apiClient); err != nil {
+			t.Fatalf("refreshIndexState() error = %v", err)
+		}
+		diff, err := resourceIndex().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), apiClient)
+		if err != nil {
+			t.Fatalf("Diff() error = %v", err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("Diff() = %v, want empty", diff)
+		}
+	}
+}
+
+func TestResourceIndex_readWithoutRenderingContent(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{"name": "test"})
+	d.SetId("test")
+
+	if err := setValues(d, mapToIndexResourceValues(d, search.Settings{})); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("rendering_config.0.facet_ordering").([]interface{}); len(got) != 0 {
+		t.Errorf("facet_ordering = %v, want empty", got)
+	}
+}
+
+func TestResourceIndex_readWithRestrictiveResponseFields(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			for _, param := range []string{"responseFields", "attributesToRetrieve"} {
+				if r.URL.Query().Has(param) {
+					t.Errorf("settings are requested with %s=%s", param, r.URL.Query().Get(param))
+				}
+			}
+			_, _ = w.Write([]byte(`{
+  "responseFields": ["hits"],
+  "attributesToRetrieve": ["title"],
+  "searchableAttributes": ["title", "description"],
+  "hitsPerPage": 50
+}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name": "test",
+		"advanced_config": []interface{}{map[string]interface{}{
+			"response_fields": []interface{}{"hits"},
+		}},
+	})
+	d.SetId("test")
+
+	if err := refreshIndexState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshIndexState() error = %v", err)
+	}
+	if got, want := castStringSet(d.Get("advanced_config.0.response_fields")), []string{"hits"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("response_fields = %v, want %v", got, want)
+	}
+	if got, want := castStringList(d.Get("attributes_config.0.searchable_attributes")), []string{"title", "description"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("searchable_attributes = %v, want %v", got, want)
+	}
+	if got := d.Get("pagination_config.0.hits_per_page").(int); got != 50 {
+		t.Errorf("hits_per_page = %v, want 50", got)
+	}
+}
+
+func TestResourceIndex_modeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		want string
+	}{
+		{
+			name: "neural search",
+			raw:  map[string]interface{}{"name": "test", "mode": "neuralSearch"},
+			want: "neuralSearch",
+		},
+		{
+			name: "keyword search",
+			raw:  map[string]interface{}{"name": "test", "mode": "keywordSearch"},
+			want: "keywordSearch",
+		},
+		{
+			name: "default mode",
+			raw:  map[string]interface{}{"name": "test"},
+			want: "keywordSearch",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceIndex().Schema, tt.raw)
+			d.SetId("test")
+
+			b, err := json.Marshal(mapToIndexSettings(d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var readSettings search.Settings
+			if err := json.Unmarshal(b, &readSettings); err != nil {
+				t.Fatal(err)
+			}
+			if err := setValues(d, mapToIndexResourceValues(d, readSettings)); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Get("mode").(string); got != tt.want {
+				t.Errorf("mode = %v, want %v, saved settings: %s", got, tt.want, b)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_invalidMode(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{"name": "test", "mode": "semanticSearch"}
+	if diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("Validate() error = nil, want invalid mode error")
+	}
+}
+
+func TestResourceIndex_validateLanguageSettingsNotConflicting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		languagesConfig map[string]interface{}
+		wantErr         bool
+	}{
+		{
+			name:            "ignore plurals for languages",
+			languagesConfig: map[string]interface{}{"ignore_plurals": false, "ignore_plurals_for": []interface{}{"en"}},
+			wantErr:         false,
+		},
+		{
+			name:            "ignore plurals for all languages",
+			languagesConfig: map[string]interface{}{"ignore_plurals": true, "ignore_plurals_for": []interface{}{}},
+			wantErr:         false,
+		},
+		{
+			name:            "ignore plurals enabled in both forms",
+			languagesConfig: map[string]interface{}{"ignore_plurals": true, "ignore_plurals_for": []interface{}{"en"}},
+			wantErr:         true,
+		},
+		{
+			name:            "remove stop words enabled in both forms",
+			languagesConfig: map[string]interface{}{"remove_stop_words": true, "remove_stop_words_for": []interface{}{"en"}},
+			wantErr:         true,
+		},
+		{
+			name: "custom normalization set in both forms",
+			languagesConfig: map[string]interface{}{
+				"custom_normalization": map[string]interface{}{"ä": "ae"},
+				"custom_normalization_by_language": []interface{}{
+					map[string]interface{}{"language": "de", "normalization": map[string]interface{}{"ä": "ae"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":             "test",
+				"languages_config": []interface{}{tt.languagesConfig},
+			}
+			_, err := testResourceDiff(resourceIndex(), raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_attributesToRetrieveDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configured []interface{}
+		remote     string
+		wantDrift  bool
+	}{
+		{
+			name:       "all attributes retrieved remotely while specific attributes are configured",
+			configured: []interface{}{"title", "price"},
+			remote:     `["*"]`,
+			wantDrift:  true,
+		},
+		{
+			name:       "specific attributes retrieved remotely while all attributes are configured",
+			configured: []interface{}{"*"},
+			remote:     `["title"]`,
+			wantDrift:  true,
+		},
+		{
+			name:       "all attributes",
+			configured: []interface{}{"*"},
+			remote:     `["*"]`,
+			wantDrift:  false,
+		},
+		{
+			name:       "all attributes by default",
+			configured: []interface{}{"*"},
+			remote:     `null`,
+			wantDrift:  false,
+		},
+		{
+			name:       "same attributes in different order",
+			configured: []interface{}{"title", "price"},
+			remote:     `["price","title"]`,
+			wantDrift:  false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var settings search.Settings
+			if err := json.Unmarshal([]byte(`{"attributesToRetrieve":`+tt.remote+`}`), &settings); err != nil {
+				t.Fatal(err)
+			}
+			d := resourceIndex().Data(nil)
+			d.SetId("test")
+			if err := setValues(d, mapToIndexResourceValues(d, settings)); err != nil {
+				t.Fatal(err)
+			}
+			state := d.State()
+			state.Attributes["name"] = "test"
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"attributes_config": []interface{}{map[string]interface{}{
+					"attributes_to_retrieve": tt.configured,
+				}},
+			}
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+					_, _ = w.Write([]byte(`{"attributesToRetrieve":` + tt.remote + `}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+			diff, err := resourceIndex().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), apiClient)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			var gotDrift bool
+			if diff != nil {
+				for k := range diff.Attributes {
+					if strings.HasPrefix(k, "attributes_config.0.attributes_to_retrieve") {
+						gotDrift = true
+					}
+				}
+			}
+			if gotDrift != tt.wantDrift {
+				t.Errorf("drift of attributes_to_retrieve = %v, want %v, diff: %v", gotDrift, tt.wantDrift, diff)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnTypoSettingsWithoutTypoTolerance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		typosConfig map[string]interface{}
+		wantWarning string
+	}{
+		{
+			name: "typo settings with typo tolerance disabled",
+			typosConfig: map[string]interface{}{
+				"typo_tolerance":                  "false",
+				"min_word_size_for_1_typo":        3,
+				"disable_typo_tolerance_on_words": []interface{}{"iphone"},
+			},
+			wantWarning: "so `min_word_size_for_1_typo`, `disable_typo_tolerance_on_words` have no effect",
+		},
+		{
+			name:        "default typo settings with typo tolerance disabled",
+			typosConfig: map[string]interface{}{"typo_tolerance": "false"},
+		},
+		{
+			name: "typo settings with typo tolerance enabled",
+			typosConfig: map[string]interface{}{
+				"typo_tolerance":           "true",
+				"min_word_size_for_1_typo": 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name":         "test",
+				"typos_config": []interface{}{tt.typosConfig},
+			}
+			if tt.wantWarning == "" {
+				assertDiffWarns(t, resourceIndex(), raw, "have no effect", false)
+			} else {
+				assertDiffWarns(t, resourceIndex(), raw, tt.wantWarning, true)
+			}
+		})
+	}
+}
+
+func Test_findHighlightTagIssues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		preTag  string
+		postTag string
+		want    []string
+	}{
+		{
+			name:    "default tags",
+			preTag:  "<em>",
+			postTag: "</em>",
+		},
+		{
+			name:    "identical markdown tags",
+			preTag:  "**",
+			postTag: "**",
+		},
+		{
+			name:    "identical html tags",
+			preTag:  "<mark>",
+			postTag: "<mark>",
+			want:    []string{"`highlight_pre_tag` and `highlight_post_tag` are the same HTML tag '<mark>'"},
+		},
+		{
+			name:    "post tag not closing",
+			preTag:  "<em>",
+			postTag: "<strong>",
+			want:    []string{"`highlight_post_tag` '<strong>' doesn't close `highlight_pre_tag` '<em>'"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := findHighlightTagIssues(tt.preTag, tt.postTag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findHighlightTagIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnSuspiciousHighlightTags(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"highlight_and_snippet_config": []interface{}{map[string]interface{}{
+			"highlight_pre_tag":  "<mark>",
+			"highlight_post_tag": "<mark>",
+		}},
+	}
+	assertDiffWarns(t, resourceIndex(), raw, "highlight tags of index (test) look suspicious", true)
+}
+
+func TestResourceIndex_validateHighlightTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		preTag   string
+		wantWarn bool
+	}{
+		{
+			name:     "balanced",
+			preTag:   "<em>",
+			wantWarn: false,
+		},
+		{
+			name:     "unbalanced angle brackets",
+			preTag:   "<em",
+			wantWarn: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"highlight_and_snippet_config": []interface{}{map[string]interface{}{
+					"highlight_pre_tag": tt.preTag,
+				}},
+			}
+			diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw))
+			if diags.HasError() {
+				t.Fatalf("Validate() error = %v, want nil", diags)
+			}
+			if got := len(diags) == 1 && diags[0].Severity == diag.Warning && diags[0].Summary == "Highlight tag has unbalanced angle brackets"; got != tt.wantWarn {
+				t.Errorf("warned = %v, want %v, diags: %v", got, tt.wantWarn, diags)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnSnippetWithoutHighlight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		attributesToHighlight []interface{}
+		attributesToSnippet   []interface{}
+		wantWarn              bool
+	}{
+		{
+			name:                "snippet without highlight",
+			attributesToSnippet: []interface{}{"description:20"},
+			wantWarn:            true,
+		},
+		{
+			name:                  "snippet with highlight",
+			attributesToHighlight: []interface{}{"title"},
+			attributesToSnippet:   []interface{}{"description:20"},
+			wantWarn:              false,
+		},
+		{
+			name:                  "highlight only",
+			attributesToHighlight: []interface{}{"title"},
+			wantWarn:              false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"highlight_and_snippet_config": []interface{}{map[string]interface{}{
+					"attributes_to_highlight": tt.attributesToHighlight,
+					"attributes_to_snippet":   tt.attributesToSnippet,
+				}},
+			}
+			assertDiffWarns(t, resourceIndex(), raw, "`attributes_to_snippet` of index (test) is set but `attributes_to_highlight` is empty", tt.wantWarn)
+		})
+	}
+}
+
+func TestResourceIndex_validateQueryType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		queryType string
+		wantWarn  bool
+	}{
+		{
+			name:      "prefixAll",
+			queryType: "prefixAll",
+			wantWarn:  true,
+		},
+		{
+			name:      "prefixLast",
+			queryType: "prefixLast",
+			wantWarn:  false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"query_strategy_config": []interface{}{map[string]interface{}{
+					"query_type": tt.queryType,
+				}},
+			}
+			diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw))
+			if diags.HasError() {
+				t.Fatalf("Validate() error = %v, want nil", diags)
+			}
+			// the warning must be shown in the plan output, not only in the logs.
+			if got := len(diags) == 1 && diags[0].Severity == diag.Warning && diags[0].Summary == "`query_type` is `prefixAll`"; got != tt.wantWarn {
+				t.Errorf("warned = %v, want %v, diags: %v", got, tt.wantWarn, diags)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_validateAllowCompressionOfIntegerArray(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                           string
+		allowCompressionOfIntegerArray bool
+		wantWarn                       bool
+	}{
+		{
+			name:                           "enabled",
+			allowCompressionOfIntegerArray: true,
+			wantWarn:                       true,
+		},
+		{
+			name:                           "disabled",
+			allowCompressionOfIntegerArray: false,
+			wantWarn:                       false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"performance_config": []interface{}{map[string]interface{}{
+					"allow_compression_of_integer_array": tt.allowCompressionOfIntegerArray,
+				}},
+			}
+			diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw))
+			if diags.HasError() {
+				t.Fatalf("Validate() error = %v, want nil", diags)
+			}
+			if got := len(diags) == 1 && diags[0].Severity == diag.Warning && diags[0].Summary == "`allow_compression_of_integer_array` is enabled"; got != tt.wantWarn {
+				t.Errorf("warned = %v, want %v, diags: %v", got, tt.wantWarn, diags)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_warnAllOptionalWithOptionalWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		removeWordsIfNoResults string
+		optionalWords          []interface{}
+		wantWarn               bool
+	}{
+		{
+			name:                   "allOptional with optional words",
+			removeWordsIfNoResults: "allOptional",
+			optionalWords:          []interface{}{"the", "a"},
+			wantWarn:               true,
+		},
+		{
+			name:                   "allOptional without optional words",
+			removeWordsIfNoResults: "allOptional",
+			wantWarn:               false,
+		},
+		{
+			name:                   "lastWords with optional words",
+			removeWordsIfNoResults: "lastWords",
+			optionalWords:          []interface{}{"the", "a"},
+			wantWarn:               false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"name": "test",
+				"query_strategy_config": []interface{}{map[string]interface{}{
+					"remove_words_if_no_results": tt.removeWordsIfNoResults,
+					"optional_words":             tt.optionalWords,
+				}},
+			}
+			assertDiffWarns(t, resourceIndex(), raw, "`remove_words_if_no_results` of index (test) is `allOptional`", tt.wantWarn)
+		})
+	}
+}
+
+func TestResourceIndex_emptyHighlightTag(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name": "test",
+		"highlight_and_snippet_config": []interface{}{map[string]interface{}{
+			"highlight_pre_tag": "",
+		}},
+	}
+	if diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("Validate() error = nil, want empty highlight tag error")
+	}
+}
+
+func TestResourceIndex_replicas(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		replicas = []byte(`[]`)
+	)
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			body, _ := io.ReadAll(r.Body)
+			var settings map[string]json.RawMessage
+			_ = json.Unmarshal(body, &settings)
+			if v, ok := settings["replicas"]; ok {
+				var names []string
+				_ = json.Unmarshal(v, &names)
+				sort.Strings(names)
+				requests = append(requests, "replicas=["+strings.Join(names, ",")+"]")
+				replicas = v
+			} else {
+				requests = append(requests, "settings")
+			}
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/test":
+			requests = append(requests, "delete")
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"replicas":` + string(replicas) + `}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                "test",
+		"replicas":            []interface{}{"test_replica", "virtual(test_virtual_replica)"},
+		"deletion_protection": false,
+	})
+
+	if diags := resourceIndexCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexCreate() error = %v", diags)
+	}
+	// The replicas are set alone, not to be forwarded with the other settings.
+	if want := []string{"settings", "replicas=[test_replica,virtual(test_virtual_replica)]"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if got := d.Get("replicas").(*schema.Set); got.Len() != 2 || !got.Contains("test_replica") || !got.Contains("virtual(test_virtual_replica)") {
+		t.Errorf("replicas = %v, want [test_replica virtual(test_virtual_replica)]", got.List())
+	}
+
+	requests = nil
+	if diags := resourceIndexDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexDelete() error = %v", diags)
+	}
+	// The replicas are detached by Algolia, not to rewrite the replicas declared by the other resources.
+	if want := []string{"delete"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestResourceIndex_importReplicasWithoutDiff(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"replicas":["test_replica","virtual(test_virtual_replica)"]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := resourceIndex().Data(nil)
+	d.SetId("test")
+	if _, err := resourceIndexStateContext(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("resourceIndexStateContext() error = %v", err)
+	}
+	if got := d.Get("replicas").(*schema.Set); got.Len() != 2 || !got.Contains("test_replica") || !got.Contains("virtual(test_virtual_replica)") {
+		t.Errorf("replicas = %v, want [test_replica virtual(test_virtual_replica)]", got.List())
+	}
+
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+	}{
+		{
+			name: "replicas configured",
+			raw: map[string]interface{}{
+				"name":     "test",
+				"replicas": []interface{}{"test_replica", "virtual(test_virtual_replica)"},
+			},
+		},
+		{
+			// The replicas declared with `primary_index_name` of the replica resources must not be detached.
+			name: "replicas not configured",
+			raw:  map[string]interface{}{"name": "test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := resourceIndex().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(tt.raw), apiClient)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if diff != nil && !diff.Empty() {
+				t.Errorf("Diff() = %v, want empty", diff)
+			}
+		})
+	}
+}
+
+func TestResourceIndex_deleteWithReplicasOfOtherResources(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/settings":
+			_, _ = w.Write([]byte(`{"replicas":["test_replica","virtual(test_virtual_replica)"]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/settings":
+			requests = append(requests, "settings")
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/test":
+			requests = append(requests, "delete")
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	// The replicas are declared with `primary_index_name` and `algolia_virtual_index`, and only read here.
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                "test",
+		"deletion_protection": false,
+	})
+	d.SetId("test")
+	if diags := resourceIndexRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexRead() error = %v", diags)
+	}
+	if got := d.Get("replicas").(*schema.Set).Len(); got != 2 {
+		t.Fatalf("number of replicas = %d, want 2", got)
+	}
+
+	if diags := resourceIndexDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceIndexDelete() error = %v", diags)
+	}
+	if want := []string{"delete"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestResourceIndex_replicasConflictsWithPrimaryIndexName(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name":               "test",
+		"primary_index_name": "primary",
+		"replicas":           []interface{}{"test_replica"},
+	}
+	diags := resourceIndex().Validate(terraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "conflicts with primary_index_name") {
+		t.Errorf("Validate() error = %v, want conflicts error", diags)
+	}
+}
+
+func TestResourceIndex_deleteAlreadyDeletedIndex(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/test":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceIndex().Schema, map[string]interface{}{
+		"name":                "test",
+		"deletion_protection": false,
+	})
+	d.SetId("test")
+
+	if diags := resourceIndexDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Errorf("resourceIndexDelete() error = %v", diags)
+	}
+}
+
+// nolint:unused
+func testAccResourceIndexWithReplica(name string, replicaName string) string {
+	return `
+resource "algolia_index" "` + name + `" {
+  name = "` + name + `"
+
+  deletion_protection = false
+}
+
+resource "algolia_index" "` + replicaName + `" {
+  name               =  "` + replicaName + `"
+  primary_index_name = algolia_index.` + name + `.name
+
+  deletion_protection = false
+}
+`
+}
+
+func testAccResourceIndexWithFacetingReplica(name string, replicaName string) string {
+	return `
+resource "algolia_index" "` + name + `" {
+  name = "` + name + `"
+
+  attributes_config {
+    attributes_for_faceting = ["category"]
+  }
+
+  deletion_protection = false
+}
+
+resource "algolia_index" "` + replicaName + `" {
+  name               = "` + replicaName + `"
+  primary_index_name = algolia_index.` + name + `.name
+
+  attributes_config {
+    attributes_for_faceting = ["category"]
+  }
+
+  ranking_config {
+    ranking = ["desc(price)", "typo", "words"]
+  }
+
+  deletion_protection = false
+}
+`
+}
+
+func testAccCheckIndexObjectExists(indexName string, objectID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var object map[string]interface{}
+		if err := newTestAPIClient().searchClient.InitIndex(indexName).GetObject(objectID, &object); err != nil {
+			return fmt.Errorf("object '%s' is not found in index '%s': %w", objectID, indexName, err)
+		}
+		return nil
+	}
 }
 
 func testAccCheckIndexDestroy(s *terraform.State) error {
@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+	"golang.org/x/sync/errgroup"
+)
+
+// localizedIndicesSettingsKeys are the settings of algolia_index shared by all the localized indices.
+var localizedIndicesSettingsKeys = []string{
+	"attributes_config",
+	"ranking_config",
+	"faceting_config",
+	"rendering_config",
+	"highlight_and_snippet_config",
+	"pagination_config",
+	"typos_config",
+	"languages_config",
+	"enable_rules",
+	"enable_personalization",
+	"mode",
+	"query_strategy_config",
+	"performance_config",
+	"advanced_config",
+}
+
+// localizedIndicesConcurrency is the max number of the localized indices applied at the same time.
+const localizedIndicesConcurrency = 4
+
+func resourceLocalizedIndices() *schema.Resource {
+	indexSchema := resourceIndex().Schema
+	s := map[string]*schema.Schema{
+		"base_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Base name of the indices. The index of each locale is named `{base_name}_{locale}`.",
+		},
+		"locales": {
+			Type:     schema.TypeSet,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+			Required: true,
+			MinItems: 1,
+			Description: "Locales to create the indices for. Each locale is used as the suffix of the index name, " +
+				"and as `query_languages` and `index_languages` of the index (e.g. `en`, `ja`, `pt-br`).",
+		},
+		"index_names": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "The map of the locale to the name of its index.",
+		},
+		"deletion_protection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to allow Terraform to delete the indices, including the ones of the locales removed from `locales`.",
+		},
+	}
+	for _, key := range localizedIndicesSettingsKeys {
+		s[key] = indexSchema[key]
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceLocalizedIndicesCreate,
+		ReadContext:   resourceLocalizedIndicesRead,
+		UpdateContext: resourceLocalizedIndicesUpdate,
+		DeleteContext: resourceLocalizedIndicesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLocalizedIndicesStateContext,
+		},
+		CustomizeDiff: customdiff.All(
+			validateLocalizedIndicesLanguagesNotSet,
+			validateSearchableAttributesNotDuplicated,
+			validateRankingNotDuplicated,
+			validateLanguageSettingsNotConflicting,
+			validateMinWordSizesForTypos,
+		),
+		Description: `A configuration for the indices of multiple locales sharing the same settings. Each locale gets its own index named ` + "`{base_name}_{locale}`" + ` with the locale as ` + "`query_languages`" + ` and ` + "`index_languages`" + `.
+
+The settings blocks are the same as ` + "`algolia_index`" + `. The settings in state are read from the index of the first locale in alphabetical order, so the drift in the indices of the other locales is not detected.
+The warning about the same ` + "`min_word_size_for_1_typo`" + ` and ` + "`min_word_size_for_2_typos`" + ` is written only to the provider logs, which are shown with ` + "`TF_LOG=WARN`" + `.
+`,
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(1 * time.Hour),
+		},
+		Schema: s,
+	}
+}
+
+func resourceLocalizedIndicesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setLocalizedIndicesSettings(ctx, d, m, castStringSet(d.Get("locales")), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("base_name").(string))
+
+	return resourceLocalizedIndicesRead(ctx, d, m)
+}
+
+func resourceLocalizedIndicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshLocalizedIndicesState(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceLocalizedIndicesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("locales") {
+		o, n := d.GetChange("locales")
+		removedLocales := castStringSet(o.(*schema.Set).Difference(n.(*schema.Set)))
+		if len(removedLocales) > 0 {
+			if d.Get("deletion_protection").(bool) {
+				return diag.Errorf("cannot delete the indices of locales %v without setting deletion_protection=false and running `terraform apply`", removedLocales)
+			}
+			if err := deleteLocalizedIndices(ctx, d, m, removedLocales); err != nil {
+				return diag.FromErr(algoliautil.HumanizeError(err))
+			}
+		}
+	}
+
+	if err := setLocalizedIndicesSettings(ctx, d, m, castStringSet(d.Get("locales")), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLocalizedIndicesRead(ctx, d, m)
+}
+
+func resourceLocalizedIndicesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot destroy indices without setting deletion_protection=false and running `terraform apply`")
+	}
+
+	if err := deleteLocalizedIndices(ctx, d, m, castStringSet(d.Get("locales"))); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return nil
+}
+
+func resourceLocalizedIndicesStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// The locales can't be listed from the base name, so they are given as `{base_name}:{locale},{locale}...`.
+	baseName, locales, err := parseLocalizedIndicesImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("base_name", baseName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("locales", locales); err != nil {
+		return nil, err
+	}
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+	d.SetId(baseName)
+	if err := refreshLocalizedIndicesState(ctx, d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("none of the indices of '%s' is found", baseName)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func refreshLocalizedIndicesState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*apiClient)
+
+	locales := castStringSet(d.Get("locales"))
+	sort.Strings(locales)
+
+	var existingLocales []string
+	var representativeSettings *search.Settings
+	indexNames := map[string]interface{}{}
+	for _, locale := range locales {
+		indexName := localizedIndexName(d.Id(), locale)
+		settings, err := apiClient.searchClient.InitIndex(indexName).GetSettings(ctx)
+		if err != nil {
+			if algoliautil.IsNotFoundError(err) {
+				tflog.Warn(ctx, fmt.Sprintf("index (%s) of locale (%s) not found, removing the locale from state", indexName, locale))
+				continue
+			}
+			return algoliautil.HumanizeError(fmt.Errorf("failed to get settings of index (%s): %w", indexName, err))
+		}
+		existingLocales = append(existingLocales, locale)
+		indexNames[locale] = indexName
+		if representativeSettings == nil {
+			representativeSettings = &settings
+		}
+	}
+	if len(existingLocales) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("none of the indices of (%s) found, removing from state", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	values := mapToIndexResourceValues(d, *representativeSettings)
+	// `name`, `primary_index_name` and `virtual` are not the fields of this resource.
+	delete(values, "name")
+	delete(values, "primary_index_name")
+	delete(values, "virtual")
+	// The languages are set per locale, so keep the shared configuration as is.
+	languagesConfig := values["languages_config"].([]interface{})[0].(map[string]interface{})
+	languagesConfig["query_languages"] = castStringSet(d.Get("languages_config.0.query_languages"))
+	languagesConfig["index_languages"] = castStringSet(d.Get("languages_config.0.index_languages"))
+	values["base_name"] = d.Id()
+	values["locales"] = existingLocales
+	values["index_names"] = indexNames
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setLocalizedIndicesSettings applies the shared settings with the languages of each locale to the indices of the locales.
+func setLocalizedIndicesSettings(ctx context.Context, d *schema.ResourceData, m interface{}, locales []string, timeout time.Duration) error {
+	apiClient := m.(*apiClient)
+
+	baseName := d.Get("base_name").(string)
+	waitForTask := shouldWaitForTask(d, apiClient)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(localizedIndicesConcurrency)
+	for _, locale := range locales {
+		locale := locale
+		// The settings are mapped for each locale since ResourceData isn't safe for concurrent use.
+		settings := mapToLocalizedIndexSettings(d, locale)
+		eg.Go(func() error {
+			indexName := localizedIndexName(baseName, locale)
+			index := apiClient.searchClient.InitIndex(indexName)
+			err := retryWrite(ctx, timeout, func() error {
+				return setIndexSettings(index, settings, false, nil, waitForTask)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to set settings of index (%s): %w", indexName, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// deleteLocalizedIndices deletes the indices of the locales. The indices which have been deleted already are ignored.
+func deleteLocalizedIndices(ctx context.Context, d *schema.ResourceData, m interface{}, locales []string) error {
+	apiClient := m.(*apiClient)
+
+	baseName := d.Get("base_name").(string)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(localizedIndicesConcurrency)
+	for _, locale := range locales {
+		locale := locale
+		indexName := localizedIndexName(baseName, locale)
+		eg.Go(func() error {
+			res, err := apiClient.searchClient.InitIndex(indexName).Delete(ctx)
+			if err != nil {
+				if algoliautil.IsNotFoundError(err) {
+					return nil
+				}
+				return fmt.Errorf("failed to delete index (%s): %w", indexName, err)
+			}
+			if err := res.Wait(ctx); err != nil {
+				return fmt.Errorf("failed to delete index (%s): %w", indexName, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// validateLocalizedIndicesLanguagesNotSet returns an error if the languages are set in `languages_config`
+// since they are set per locale.
+func validateLocalizedIndicesLanguagesNotSet(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("languages_config") {
+		return nil
+	}
+	for _, key := range []string{"query_languages", "index_languages"} {
+		if len(castStringSet(d.Get("languages_config.0."+key))) > 0 {
+			return fmt.Errorf("`languages_config.0.%s` can't be set since it's set to the locale of each index", key)
+		}
+	}
+	return nil
+}
+
+func mapToLocalizedIndexSettings(d *schema.ResourceData, locale string) search.Settings {
+	settings := mapToIndexSettings(d)
+	settings.QueryLanguages = opt.QueryLanguages(locale)
+	settings.IndexLanguages = opt.IndexLanguages(locale)
+	return settings
+}
+
+func localizedIndexName(baseName, locale string) string {
+	return fmt.Sprintf("%s_%s", baseName, locale)
+}
+
+// parseLocalizedIndicesImportID parses `{base_name}:{locale},{locale}...` format import id.
+func parseLocalizedIndicesImportID(id string) (string, []string, error) {
+	baseName, localesStr, ok := strings.Cut(id, ":")
+	if !ok || baseName == "" || localesStr == "" {
+		return "", nil, fmt.Errorf("'%s' is invalid format for import id. it must be '{base_name}:{locale},{locale}...'", id)
+	}
+	return baseName, strings.Split(localesStr, ","), nil
+}
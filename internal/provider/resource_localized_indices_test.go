@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceLocalizedIndices(t *testing.T) {
+	baseName := randResourceID(80)
+	resourceName := fmt.Sprintf("algolia_localized_indices.%s", baseName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceLocalizedIndices(baseName, `["en", "ja"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "base_name", baseName),
+					testCheckResourceListAttr(resourceName, "locales", []string{"en", "ja"}),
+					resource.TestCheckResourceAttr(resourceName, "index_names.en", baseName+"_en"),
+					resource.TestCheckResourceAttr(resourceName, "index_names.ja", baseName+"_ja"),
+					testCheckResourceListAttr(resourceName, "attributes_config.0.searchable_attributes", []string{"title", "description"}),
+					resource.TestCheckResourceAttr(resourceName, "pagination_config.0.hits_per_page", "30"),
+				),
+			},
+			{
+				Config: testAccResourceLocalizedIndices(baseName, `["en", "ja", "fr"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceListAttr(resourceName, "locales", []string{"en", "fr", "ja"}),
+					resource.TestCheckResourceAttr(resourceName, "index_names.fr", baseName+"_fr"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateId:           fmt.Sprintf("%s:en,fr,ja", baseName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+		CheckDestroy: testAccCheckLocalizedIndicesDestroy,
+	})
+}
+
+func TestResourceLocalizedIndices_createWithMultipleLocales(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	languagesByIndex := map[string][]string{}
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		indexName := strings.Split(strings.TrimPrefix(r.URL.Path, "/1/indexes/"), "/")[0]
+		switch {
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/settings"):
+			var settings struct {
+				HitsPerPage    int      `json:"hitsPerPage"`
+				QueryLanguages []string `json:"queryLanguages"`
+				IndexLanguages []string `json:"indexLanguages"`
+			}
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &settings)
+			if settings.HitsPerPage != 30 {
+				t.Errorf("hitsPerPage of index (%s) = %v, want 30", indexName, settings.HitsPerPage)
+			}
+			if !reflect.DeepEqual(settings.QueryLanguages, settings.IndexLanguages) {
+				t.Errorf("queryLanguages %v and indexLanguages %v of index (%s) differ", settings.QueryLanguages, settings.IndexLanguages, indexName)
+			}
+			mu.Lock()
+			languagesByIndex[indexName] = settings.QueryLanguages
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/task/1"):
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/settings"):
+			mu.Lock()
+			languages, _ := json.Marshal(languagesByIndex[indexName])
+			mu.Unlock()
+			_, _ = fmt.Fprintf(w, `{"hitsPerPage":30,"queryLanguages":%s,"indexLanguages":%s}`, languages, languages)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceLocalizedIndices().Schema, map[string]interface{}{
+		"base_name":         "products",
+		"locales":           []interface{}{"en", "ja", "fr", "de", "pt-br"},
+		"pagination_config": []interface{}{map[string]interface{}{"hits_per_page": 30}},
+	})
+
+	if diags := resourceLocalizedIndicesCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceLocalizedIndicesCreate() error = %v", diags)
+	}
+
+	want := map[string][]string{
+		"products_de":    {"de"},
+		"products_en":    {"en"},
+		"products_fr":    {"fr"},
+		"products_ja":    {"ja"},
+		"products_pt-br": {"pt-br"},
+	}
+	if !reflect.DeepEqual(languagesByIndex, want) {
+		t.Errorf("languages by index = %v, want %v", languagesByIndex, want)
+	}
+	if got := d.Id(); got != "products" {
+		t.Errorf("id = %v, want products", got)
+	}
+	if got := d.Get("index_names.pt-br").(string); got != "products_pt-br" {
+		t.Errorf("index_names.pt-br = %v, want products_pt-br", got)
+	}
+	// the languages of each locale must not leak into the shared settings.
+	if got := castStringSet(d.Get("languages_config.0.query_languages")); len(got) != 0 {
+		t.Errorf("languages_config.0.query_languages = %v, want empty", got)
+	}
+	if got := d.Get("pagination_config.0.hits_per_page").(int); got != 30 {
+		t.Errorf("pagination_config.0.hits_per_page = %v, want 30", got)
+	}
+}
+
+func TestResourceLocalizedIndices_readDeletedLocale(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/products_en/settings":
+			_, _ = w.Write([]byte(`{"queryLanguages":["en"],"indexLanguages":["en"]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/products_ja/settings":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceLocalizedIndices().Schema, map[string]interface{}{
+		"base_name": "products",
+		"locales":   []interface{}{"en", "ja"},
+	})
+	d.SetId("products")
+
+	if diags := resourceLocalizedIndicesRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceLocalizedIndicesRead() error = %v", diags)
+	}
+	if got := castStringSet(d.Get("locales")); !reflect.DeepEqual(got, []string{"en"}) {
+		t.Errorf("locales = %v, want [en]", got)
+	}
+}
+
+func TestResourceLocalizedIndices_delete(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var deletedIndices []string
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/products_fr":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deletedIndices = append(deletedIndices, strings.TrimPrefix(r.URL.Path, "/1/indexes/"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/task/1"):
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceLocalizedIndices().Schema, map[string]interface{}{
+		"base_name":           "products",
+		"locales":             []interface{}{"en", "ja", "fr"},
+		"deletion_protection": false,
+	})
+	d.SetId("products")
+
+	if diags := resourceLocalizedIndicesDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceLocalizedIndicesDelete() error = %v", diags)
+	}
+	sort.Strings(deletedIndices)
+	if want := []string{"products_en", "products_ja"}; !reflect.DeepEqual(deletedIndices, want) {
+		t.Errorf("deleted indices = %v, want %v", deletedIndices, want)
+	}
+}
+
+func TestResourceLocalizedIndices_validateLocalizedIndicesLanguagesNotSet(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"base_name":        "products",
+		"locales":          []interface{}{"en", "ja"},
+		"languages_config": []interface{}{map[string]interface{}{"query_languages": []interface{}{"en"}}},
+	}
+	_, err := resourceLocalizedIndices().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), &apiClient{})
+	if err == nil || !regexp.MustCompile("`languages_config.0.query_languages` can't be set").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want languages error", err)
+	}
+}
+
+func Test_parseLocalizedIndicesImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		id           string
+		wantBaseName string
+		wantLocales  []string
+		wantErr      bool
+	}{
+		{
+			name:         "base name with locales",
+			id:           "products:en,ja",
+			wantBaseName: "products",
+			wantLocales:  []string{"en", "ja"},
+		},
+		{
+			name:    "base name only",
+			id:      "products",
+			wantErr: true,
+		},
+		{
+			name:    "without locales",
+			id:      "products:",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			baseName, locales, err := parseLocalizedIndicesImportID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLocalizedIndicesImportID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if baseName != tt.wantBaseName || !reflect.DeepEqual(locales, tt.wantLocales) {
+				t.Errorf("parseLocalizedIndicesImportID() = %v, %v, want %v, %v", baseName, locales, tt.wantBaseName, tt.wantLocales)
+			}
+		})
+	}
+}
+
+func testAccResourceLocalizedIndices(baseName string, locales string) string {
+	return `
+resource "algolia_localized_indices" "` + baseName + `" {
+  base_name = "` + baseName + `"
+  locales   = ` + locales + `
+
+  attributes_config {
+    searchable_attributes = ["title", "description"]
+  }
+  pagination_config {
+    hits_per_page = 30
+  }
+
+  deletion_protection = false
+}
+`
+}
+
+func testAccCheckLocalizedIndicesDestroy(s *terraform.State) error {
+	apiClient := newTestAPIClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "algolia_localized_indices" {
+			continue
+		}
+
+		for key, indexName := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "index_names.") || key == "index_names.%" {
+				continue
+			}
+			exists, err := apiClient.searchClient.InitIndex(indexName).Exists()
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("index '%s' still exists", indexName)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/personalization"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourcePersonalizationStrategy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePersonalizationStrategyCreate,
+		ReadContext:   resourcePersonalizationStrategyRead,
+		UpdateContext: resourcePersonalizationStrategyUpdate,
+		DeleteContext: resourcePersonalizationStrategyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePersonalizationStrategyStateContext,
+		},
+		Description: `A configuration for the personalization strategy of the application. To get more information about personalization, see the [Official Documentation](https://www.algolia.com/doc/guides/personalization/what-is-personalization/).
+
+※ The personalization strategy is unique per application, so only one resource should be defined for an application. Destroying the resource resets the strategy to an empty one.
+`,
+		// https://www.algolia.com/doc/rest-api/personalization/#set-a-personalization-strategy
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      region.US,
+				ValidateFunc: validation.StringInSlice(algoliautil.ValidRegionStrings, false),
+				Description:  `Region of the personalization. "us", "eu", "de" are supported. Defaults to "us" when not specified.`,
+			},
+			"event_scoring": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Scores associated with the events.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the event sent with the Insights API.",
+						},
+						"event_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"click", "conversion", "view"}, false),
+							Description:  "Type of the event. Possible values are `click`, `conversion` and `view`.",
+						},
+						"score": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 100),
+							Description:  "Score of the event, between 1 and 100.",
+						},
+					},
+				},
+			},
+			"facet_scoring": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Scores associated with the facets.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"facet_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the facet. The attribute must be declared in `attributes_for_faceting` of the indices.",
+						},
+						"score": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 100),
+							Description:  "Score of the facet, between 1 and 100.",
+						},
+					},
+				},
+			},
+			"personalization_impact": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+				Description:  "The impact that personalization has on search results, between 0 (personalization disabled) and 100.",
+			},
+		},
+	}
+}
+
+func resourcePersonalizationStrategyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+	personalizationClient := newPersonalizationClient(d, m)
+
+	if _, err := personalizationClient.SetPersonalizationStrategy(mapToPersonalizationStrategy(d), ctx); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	// The strategy is unique per application.
+	d.SetId(apiClient.appID)
+
+	return resourcePersonalizationStrategyRead(ctx, d, m)
+}
+
+func resourcePersonalizationStrategyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshPersonalizationStrategyState(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePersonalizationStrategyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	personalizationClient := newPersonalizationClient(d, m)
+
+	if _, err := personalizationClient.SetPersonalizationStrategy(mapToPersonalizationStrategy(d), ctx); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return resourcePersonalizationStrategyRead(ctx, d, m)
+}
+
+func resourcePersonalizationStrategyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	personalizationClient := newPersonalizationClient(d, m)
+
+	// The strategy can't be deleted, so reset it to an empty one instead.
+	emptyStrategy := personalization.Strategy{
+		EventsScoring:         []personalization.EventsScoring{},
+		FacetsScoring:         []personalization.FacetsScoring{},
+		PersonalizationImpact: opt.PersonalizationImpact(0),
+	}
+	if _, err := personalizationClient.SetPersonalizationStrategy(emptyStrategy, ctx); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return nil
+}
+
+func resourcePersonalizationStrategyStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	apiClient := m.(*apiClient)
+
+	r, id, err := parseImportRegionAndId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if id != apiClient.appID {
+		return nil, fmt.Errorf("'%s' is invalid id, it must be the application ID (%s) configured in the provider", id, apiClient.appID)
+	}
+	if r != "" {
+		if err := d.Set("region", string(r)); err != nil {
+			return nil, err
+		}
+	}
+	d.SetId(id)
+	if err := refreshPersonalizationStrategyState(ctx, d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("personalization strategy of application (%s) is not set", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func refreshPersonalizationStrategyState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	personalizationClient := newPersonalizationClient(d, m)
+
+	strategy, err := personalizationClient.GetPersonalizationStrategy(ctx)
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	// The strategy is reset to an empty one on deletion, which is regarded as not existing.
+	if isPersonalizationStrategyEmpty(strategy) && !d.IsNewResource() {
+		tflog.Warn(ctx, fmt.Sprintf("personalization strategy of application (%s) is empty, removing from state", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	var eventScoring []interface{}
+	for _, e := range strategy.EventsScoring {
+		eventScoring = append(eventScoring, map[string]interface{}{
+			"event_name": e.EventName,
+			"event_type": e.EventType,
+			"score":      e.Score,
+		})
+	}
+	var facetScoring []interface{}
+	for _, f := range strategy.FacetsScoring {
+		facetScoring = append(facetScoring, map[string]interface{}{
+			"facet_name": f.FacetName,
+			"score":      f.Score,
+		})
+	}
+
+	values := map[string]interface{}{
+		"event_scoring":          eventScoring,
+		"facet_scoring":          facetScoring,
+		"personalization_impact": strategy.PersonalizationImpact.Get(),
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapToPersonalizationStrategy(d *schema.ResourceData) personalization.Strategy {
+	strategy := personalization.Strategy{
+		EventsScoring:         []personalization.EventsScoring{},
+		FacetsScoring:         []personalization.FacetsScoring{},
+		PersonalizationImpact: opt.PersonalizationImpact(d.Get("personalization_impact").(int)),
+	}
+	for _, v := range d.Get("event_scoring").(*schema.Set).List() {
+		e := v.(map[string]interface{})
+		strategy.EventsScoring = append(strategy.EventsScoring, personalization.EventsScoring{
+			EventName: e["event_name"].(string),
+			EventType: e["event_type"].(string),
+			Score:     e["score"].(int),
+		})
+	}
+	for _, v := range d.Get("facet_scoring").(*schema.Set).List() {
+		f := v.(map[string]interface{})
+		strategy.FacetsScoring = append(strategy.FacetsScoring, personalization.FacetsScoring{
+			FacetName: f["facet_name"].(string),
+			Score:     f["score"].(int),
+		})
+	}
+	return strategy
+}
+
+func newPersonalizationClient(d *schema.ResourceData, m interface{}) *personalization.Client {
+	apiClient := m.(*apiClient)
+	r := region.Region(d.Get("region").(string))
+	return apiClient.newPersonalizationClient(r)
+}
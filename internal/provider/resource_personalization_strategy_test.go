@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourcePersonalizationStrategy(t *testing.T) {
+	resourceName := "algolia_personalization_strategy.default"
+
+	// The strategy is unique per application, so the test must not run in parallel with the other tests of it.
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePersonalizationStrategy(20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", os.Getenv("ALGOLIA_APP_ID")),
+					resource.TestCheckResourceAttr(resourceName, "region", "us"),
+					resource.TestCheckResourceAttr(resourceName, "event_scoring.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "event_scoring.*", map[string]string{
+						"event_name": "Add to cart",
+						"event_type": "conversion",
+						"score":      "50",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "facet_scoring.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "facet_scoring.*", map[string]string{
+						"facet_name": "brand",
+						"score":      "100",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "personalization_impact", "20"),
+				),
+			},
+			{
+				Config: testAccResourcePersonalizationStrategy(60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "personalization_impact", "60"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("us/%s", os.Getenv("ALGOLIA_APP_ID")),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckPersonalizationStrategyDestroy,
+	})
+}
+
+func TestResourcePersonalizationStrategy_createAndRead(t *testing.T) {
+	t.Parallel()
+
+	var strategy []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/strategies/personalization":
+			strategy, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"status":200,"message":"Strategy was successfully updated"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/strategies/personalization":
+			_, _ = w.Write(strategy)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePersonalizationStrategy().Schema, map[string]interface{}{
+		"event_scoring": []interface{}{
+			map[string]interface{}{"event_name": "Add to cart", "event_type": "conversion", "score": 50},
+		},
+		"facet_scoring": []interface{}{
+			map[string]interface{}{"facet_name": "brand", "score": 100},
+		},
+		"personalization_impact": 20,
+	})
+
+	if diags := resourcePersonalizationStrategyCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourcePersonalizationStrategyCreate() error = %v", diags)
+	}
+
+	want := `{"eventsScoring":[{"eventName":"Add to cart","eventType":"conversion","score":50}],"facetsScoring":[{"facetName":"brand","score":100}],"personalizationImpact":20}`
+	if ok, _ := jsonBytesEqual(strategy, []byte(want)); !ok {
+		t.Errorf("strategy = %s, want %s", strategy, want)
+	}
+	if got := d.Id(); got != "test" {
+		t.Errorf("id = %v, want the app ID", got)
+	}
+	if got := d.Get("personalization_impact").(int); got != 20 {
+		t.Errorf("personalization_impact = %v, want %v", got, 20)
+	}
+	if got := d.Get("event_scoring").(*schema.Set).Len(); got != 1 {
+		t.Errorf("event_scoring has %v elements, want %v", got, 1)
+	}
+}
+
+func TestResourcePersonalizationStrategy_deleteResetsStrategy(t *testing.T) {
+	t.Parallel()
+
+	var strategy []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/strategies/personalization":
+			strategy, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"status":200,"message":"Strategy was successfully updated"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePersonalizationStrategy().Schema, map[string]interface{}{})
+	d.SetId("test")
+
+	if diags := resourcePersonalizationStrategyDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourcePersonalizationStrategyDelete() error = %v", diags)
+	}
+
+	want := `{"eventsScoring":[],"facetsScoring":[],"personalizationImpact":0}`
+	if ok, _ := jsonBytesEqual(strategy, []byte(want)); !ok {
+		t.Errorf("strategy = %s, want %s", strategy, want)
+	}
+}
+
+func TestResourcePersonalizationStrategy_import(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/strategies/personalization":
+			_, _ = w.Write([]byte(`{"eventsScoring":[],"facetsScoring":[],"personalizationImpact":0}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr *regexp.Regexp
+	}{
+		{
+			name:    "other application",
+			id:      "eu/other",
+			wantErr: regexp.MustCompile(`'other' is invalid id, it must be the application ID \(test\)`),
+		},
+		{
+			name:    "empty strategy",
+			id:      "eu/test",
+			wantErr: regexp.MustCompile(`personalization strategy of application \(test\) is not set`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourcePersonalizationStrategy().Schema, map[string]interface{}{})
+			d.SetId(tt.id)
+
+			_, err := resourcePersonalizationStrategyStateContext(context.Background(), d, apiClient)
+			if err == nil || !tt.wantErr.MatchString(err.Error()) {
+				t.Errorf("resourcePersonalizationStrategyStateContext() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func testAccResourcePersonalizationStrategy(personalizationImpact int) string {
+	return fmt.Sprintf(`
+resource "algolia_personalization_strategy" "default" {
+  event_scoring {
+    event_name = "Add to cart"
+    event_type = "conversion"
+    score      = 50
+  }
+  event_scoring {
+    event_name = "Product viewed"
+    event_type = "view"
+    score      = 10
+  }
+
+  facet_scoring {
+    facet_name = "brand"
+    score      = 100
+  }
+
+  personalization_impact = %d
+}
+`, personalizationImpact)
+}
+
+func testAccCheckPersonalizationStrategyDestroy(s *terraform.State) error {
+	apiClient := newTestAPIClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "algolia_personalization_strategy" {
+			continue
+		}
+
+		strategy, err := apiClient.newPersonalizationClient(region.Region(rs.Primary.Attributes["region"])).GetPersonalizationStrategy()
+		if err != nil {
+			return err
+		}
+		if !isPersonalizationStrategyEmpty(strategy) {
+			return fmt.Errorf("personalization strategy of application '%s' is not reset", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -100,11 +100,12 @@ func resourceQuerySuggestions() *schema.Resource {
 							Type:     schema.TypeList,
 							Optional: true,
 							Elem: &schema.Schema{
-								Type: schema.TypeList,
-								Elem: &schema.Schema{Type: schema.TypeString},
+								Type:     schema.TypeList,
+								MinItems: 1,
+								Elem:     &schema.Schema{Type: schema.TypeString},
 							},
 							Description: `List of facet attributes used to generate Query Suggestions. The resulting suggestions are every combination of the facets in the nested list 
-(e.g., (facetA and facetB) and facetC).
+(e.g., (facetA and facetB) and facetC). Each nested list must contain at least one facet.
 ` + "```" + `
 [
   ["facetA", "facetB"],
@@ -149,7 +150,7 @@ func resourceQuerySuggestionsCreate(ctx context.Context, d *schema.ResourceData,
 	indexName := d.Get("index_name").(string)
 	err := suggestionsClient.CreateConfig(mapToQuerySuggestionsIndexConfig(d), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(indexName)
@@ -165,12 +166,16 @@ func resourceQuerySuggestionsRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceQuerySuggestionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChanges("source_indices", "languages", "exclude") {
+		return resourceQuerySuggestionsRead(ctx, d, m)
+	}
+
 	suggestionsClient := newSuggestionsClient(d, m)
 
 	indexName := d.Get("index_name").(string)
 	err := suggestionsClient.UpdateConfig(mapToQuerySuggestionsIndexConfig(d), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(indexName)
@@ -184,7 +189,7 @@ func resourceQuerySuggestionsDelete(ctx context.Context, d *schema.ResourceData,
 	indexName := d.Get("index_name").(string)
 	err := suggestionsClient.DeleteConfig(indexName, ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
@@ -233,11 +238,20 @@ func refreshQuerySuggestionsState(ctx context.Context, d *schema.ResourceData, m
 			d.SetId("")
 			return nil
 		}
+		return algoliautil.HumanizeError(err)
+	}
+
+	values := mapToQuerySuggestionsValues(querySuggestionsIndexConfig)
+	if err := setValues(d, values); err != nil {
 		return err
 	}
 
+	return nil
+}
+
+func mapToQuerySuggestionsValues(indexConfig *suggestions.IndexConfiguration) map[string]interface{} {
 	var sourceIndices []interface{}
-	for _, sourceIndex := range querySuggestionsIndexConfig.SourceIndices {
+	for _, sourceIndex := range indexConfig.SourceIndices {
 		var facets []map[string]interface{}
 		for _, f := range sourceIndex.Facets {
 			facets = append(facets, map[string]interface{}{
@@ -256,17 +270,22 @@ func refreshQuerySuggestionsState(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 
-	values := map[string]interface{}{
-		"index_name":     querySuggestionsIndexConfig.IndexName,
+	return map[string]interface{}{
+		"index_name":     indexConfig.IndexName,
 		"source_indices": sourceIndices,
-		"languages":      querySuggestionsIndexConfig.Languages.StringArray,
-		"exclude":        querySuggestionsIndexConfig.Exclude,
-	}
-	if err := setValues(d, values); err != nil {
-		return err
+		"languages":      flattenQuerySuggestionsLanguages(indexConfig.Languages),
+		"exclude":        indexConfig.Exclude,
 	}
+}
 
-	return nil
+// flattenQuerySuggestionsLanguages returns the languages to be stored in the state.
+// Algolia may return the boolean form of `languages` (e.g. when it's configured on the dashboard), which can't be
+// represented by the languages set, so it's treated as no language is configured.
+func flattenQuerySuggestionsLanguages(languages suggestions.BoolOrStringArray) []string {
+	if languages.IsBool {
+		return nil
+	}
+	return languages.StringArray
 }
 
 func mapToQuerySuggestionsIndexConfig(d *schema.ResourceData) suggestions.IndexConfiguration {
@@ -279,7 +298,7 @@ func mapToQuerySuggestionsIndexConfig(d *schema.ResourceData) suggestions.IndexC
 	}
 
 	if v, ok := d.GetOk("languages"); ok {
-		indexConfig.Languages = suggestions.BoolOrStringArray{StringArray: castStringSet(v)}
+		indexConfig.Languages = suggestions.NewStringArray(castStringSet(v))
 	}
 
 	if v, ok := d.GetOk("exclude"); ok {
@@ -1,13 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -42,8 +48,7 @@ func TestAccResourceQuerySuggestions(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "index_name", indexName),
 					resource.TestCheckResourceAttr(resourceName, "region", "us"),
 					resource.TestCheckResourceAttr(resourceName, "source_indices.0.index_name", sourceIndexName),
-					testCheckResourceListAttr(resourceName, "source_indices.0.analytics_tags", []string{}),
-					testCheckResourceListAttr(resourceName, "source_indices.0.facets", []string{}),
+					testCheckResourceListAttr(resourceName, "source_indices.0.analytics_tags", []string{"mobile"}),
 					resource.TestCheckResourceAttr(resourceName, "source_indices.0.min_hits", "10"),
 					resource.TestCheckResourceAttr(resourceName, "source_indices.0.min_letters", "3"),
 					resource.TestCheckResourceAttr(resourceName, "source_indices.0.facets.0.attribute", "brand"),
@@ -52,7 +57,7 @@ func TestAccResourceQuerySuggestions(t *testing.T) {
 					testCheckResourceListAttr(resourceName, "source_indices.0.generate.1", []string{"brand", "category"}),
 					testCheckResourceListAttr(resourceName, "source_indices.0.external", []string{}),
 					testCheckResourceListAttr(resourceName, "languages", []string{"en", "ja"}),
-					resource.TestCheckNoResourceAttr(resourceName, "exclude.0"),
+					testCheckResourceListAttr(resourceName, "exclude", []string{"test"}),
 				),
 			},
 			{
@@ -66,6 +71,273 @@ func TestAccResourceQuerySuggestions(t *testing.T) {
 	})
 }
 
+func TestResourceQuerySuggestions_createWithMultipleSourceIndices(t *testing.T) {
+	t.Parallel()
+
+	var config []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/configs":
+			var err error
+			if config, err = io.ReadAll(r.Body); err != nil {
+				t.Errorf("failed to read request body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"status":200,"message":"Configuration was created"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+			_, _ = w.Write(config)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceQuerySuggestions().Schema, map[string]interface{}{
+		"index_name": "test",
+		"source_indices": []interface{}{
+			map[string]interface{}{
+				"index_name":  "source_1",
+				"min_hits":    5,
+				"min_letters": 4,
+				"facets": []interface{}{
+					map[string]interface{}{"attribute": "brand", "amount": 2},
+				},
+			},
+			map[string]interface{}{
+				"index_name":     "source_2",
+				"analytics_tags": []interface{}{"mobile"},
+				"min_hits":       10,
+				"min_letters":    3,
+				"generate":       []interface{}{[]interface{}{"brand", "category"}},
+			},
+		},
+	})
+
+	if diags := resourceQuerySuggestionsCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceQuerySuggestionsCreate() error = %v", diags)
+	}
+
+	tests := map[string]interface{}{
+		"source_indices.#":                    2,
+		"source_indices.0.index_name":         "source_1",
+		"source_indices.0.min_hits":           5,
+		"source_indices.0.min_letters":        4,
+		"source_indices.0.facets.0.attribute": "brand",
+		"source_indices.0.facets.0.amount":    2,
+		"source_indices.0.analytics_tags.#":   0,
+		"source_indices.1.index_name":         "source_2",
+		"source_indices.1.min_hits":           10,
+		"source_indices.1.min_letters":        3,
+		"source_indices.1.facets.#":           0,
+		"source_indices.1.analytics_tags.#":   1,
+		"source_indices.1.generate.0.1":       "category",
+	}
+	for key, want := range tests {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestResourceQuerySuggestions_updateWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+			_, _ = w.Write([]byte(`{"indexName":"test","sourceIndices":[{"indexName":"source"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := resourceQuerySuggestions().Data(&terraform.InstanceState{
+		ID: "test",
+		Attributes: map[string]string{
+			"index_name":                  "test",
+			"region":                      "us",
+			"source_indices.#":            "1",
+			"source_indices.0.index_name": "source",
+		},
+	})
+
+	if diags := resourceQuerySuggestionsUpdate(context.Background(), d, apiClient); diags.HasError() {
+		t.Errorf("resourceQuerySuggestionsUpdate() error = %v", diags)
+	}
+}
+
+func TestResourceQuerySuggestions_readLanguages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		languages string
+		want      []string
+	}{
+		{
+			name:      "string array",
+			languages: `["ja","en"]`,
+			want:      []string{"en", "ja"},
+		},
+		{
+			name:      "boolean",
+			languages: `true`,
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+					_, _ = w.Write([]byte(`{"indexName":"test","sourceIndices":[{"indexName":"source"}],"languages":` + tt.languages + `}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := resourceQuerySuggestions().Data(&terraform.InstanceState{
+				ID:         "test",
+				Attributes: map[string]string{"index_name": "test", "region": "us"},
+			})
+			if diags := resourceQuerySuggestionsRead(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceQuerySuggestionsRead() error = %v", diags)
+			}
+
+			// languages is a set, so the order returned by Algolia doesn't matter.
+			got := castStringSet(d.Get("languages"))
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("languages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceQuerySuggestions_readSourceIndices(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+			_, _ = w.Write([]byte(`{
+  "indexName": "test",
+  "sourceIndices": [
+    {
+      "indexName": "source",
+      "analyticsTags": ["mobile", "web"],
+      "facets": [{"attribute": "brand", "amount": 3}],
+      "minHits": 5,
+      "minLetters": 4,
+      "generate": [["brand"], ["brand", "category"]],
+      "external": ["external_suggestions"]
+    }
+  ],
+  "exclude": ["test"]
+}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := resourceQuerySuggestions().Data(&terraform.InstanceState{
+		ID:         "test",
+		Attributes: map[string]string{"index_name": "test", "region": "us"},
+	})
+	if diags := resourceQuerySuggestionsRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceQuerySuggestionsRead() error = %v", diags)
+	}
+
+	tests := map[string]interface{}{
+		"source_indices.0.index_name":         "source",
+		"source_indices.0.analytics_tags.#":   2,
+		"source_indices.0.facets.0.attribute": "brand",
+		"source_indices.0.facets.0.amount":    3,
+		"source_indices.0.min_hits":           5,
+		"source_indices.0.min_letters":        4,
+		"source_indices.0.generate.#":         2,
+		"source_indices.0.generate.1.1":       "category",
+		"source_indices.0.external.#":         1,
+		"exclude.#":                           1,
+	}
+	for key, want := range tests {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if got, want := castStringSet(d.Get("source_indices.0.external")), []string{"external_suggestions"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("source_indices.0.external = %v, want %v", got, want)
+	}
+}
+
+func TestResourceQuerySuggestions_externalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var config []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/configs":
+			config, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"status":200,"message":"Configuration was created"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/configs/test":
+			_, _ = w.Write(config)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceQuerySuggestions().Schema, map[string]interface{}{
+		"index_name": "test",
+		"source_indices": []interface{}{
+			map[string]interface{}{
+				"index_name": "source",
+				"external":   []interface{}{"external_suggestions"},
+			},
+		},
+		"exclude": []interface{}{"test"},
+	})
+
+	if diags := resourceQuerySuggestionsCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceQuerySuggestionsCreate() error = %v", diags)
+	}
+	if !strings.Contains(string(config), `"external":["external_suggestions"]`) {
+		t.Errorf("created config = %s, want external", config)
+	}
+	if got, want := castStringSet(d.Get("source_indices.0.external")), []string{"external_suggestions"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("source_indices.0.external = %v, want %v", got, want)
+	}
+	// generate is omitted when it isn't configured.
+	if strings.Contains(string(config), `"generate"`) {
+		t.Errorf("created config = %s, want no generate", config)
+	}
+	if got := d.Get("source_indices.0.generate.#"); got != 0 {
+		t.Errorf("source_indices.0.generate.# = %v, want 0", got)
+	}
+}
+
+func TestResourceQuerySuggestions_emptyGenerateGroup(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"source_indices": []interface{}{
+			map[string]interface{}{
+				"index_name": "source",
+				"generate":   []interface{}{[]interface{}{"brand"}, []interface{}{}},
+			},
+		},
+	}
+	if diags := resourceQuerySuggestions().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("Validate() error = nil, want empty generate group error")
+	}
+}
+
 func testAccResourceQuerySuggestions(indexName, sourceIndexName string) string {
 	return `
 resource "algolia_index" "` + indexName + `" {
@@ -90,7 +362,7 @@ resource "algolia_query_suggestions" "` + indexName + `" {
 `
 }
 
-// TODO: add missing attributes
+// `external` isn't covered since it requires an external Query Suggestions index.
 func testAccResourceQuerySuggestionsUpdate(indexName, sourceIndexName string) string {
 	return `
 resource "algolia_index" "` + sourceIndexName + `" {
@@ -102,9 +374,10 @@ resource "algolia_query_suggestions" "` + indexName + `" {
   index_name = "` + indexName + `"
 
   source_indices {
-    index_name  = algolia_index.` + sourceIndexName + `.name
-    min_hits    = 10
-    min_letters = 3
+    index_name     = algolia_index.` + sourceIndexName + `.name
+    analytics_tags = ["mobile"]
+    min_hits       = 10
+    min_letters    = 3
     facets {
       attribute = "brand"
       amount    = 2
@@ -113,6 +386,7 @@ resource "algolia_query_suggestions" "` + indexName + `" {
   }
 
   languages = ["en", "ja"]
+  exclude   = ["test"]
 }
 `
 }
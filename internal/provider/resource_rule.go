@@ -5,7 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -28,6 +30,10 @@ func resourceRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceRuleStateContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateRuleConditionsNotEmpty,
+			validateKnownConsequenceParams,
+		),
 		Description: "A configuration for a Rule.  To get more information about rules, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/rules/rules-overview/).",
 		// https://www.algolia.com/doc/api-reference/api-methods/save-rule/#parameters
 		Schema: map[string]*schema.Schema{
@@ -47,6 +53,7 @@ func resourceRule() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Computed:    true,
+				MaxItems:    25,
 				Description: "A list of conditions that should apply to activate a Rule. You can use up to 25 conditions per Rule.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -54,27 +61,27 @@ func resourceRule() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 							Description: `Query pattern syntax.
-Query patterns are expressed as a string with a specific syntax. A pattern is a sequence of tokens, which can be either:
+	Query patterns are expressed as a string with a specific syntax. A pattern is a sequence of tokens, which can be either:
 
-- Facet value placeholder: ` + "`{facet:$facet_name}`" + `. Example: ` + "`{facet:brand}`" + `.
-- Literal: the world itself. Example: Algolia.
-Special characters (` + "`*`, `{`, `}`, `:` and `\\`" + `) must be escaped by preceding them with a backslash (` + "\\" + `) if they are to be treated as literals.
+	- Facet value placeholder: ` + "`{facet:$facet_name}`" + `. Example: ` + "`{facet:brand}`" + `.
+	- Literal: the world itself. Example: Algolia.
+	Special characters (` + "`*`, `{`, `}`, `:` and `\\`" + `) must be escaped by preceding them with a backslash (` + "\\" + `) if they are to be treated as literals.
 
-This parameter goes hand in hand with the ` + "`anchoring`" + ` parameter. If you’re creating a Rule that depends on a specific query, you must specify the pattern and anchoring. The empty ` + "`\"\"`" + ` pattern is only allowed when ` + "`anchoring`" + ` is set to ` + "`is`" + `.
+	This parameter goes hand in hand with the ` + "`anchoring`" + ` parameter. If you’re creating a Rule that depends on a specific query, you must specify the pattern and anchoring. The empty ` + "`\"\"`" + ` pattern is only allowed when ` + "`anchoring`" + ` is set to ` + "`is`" + `.
 
-Otherwise, you can omit both.
-`,
+	Otherwise, you can omit both.
+	`,
 						},
 						"anchoring": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"is", "startsWith", "endsWith", "contains"}, false),
 							Description: `Whether the pattern parameter must match the beginning or the end of the query string, or both, or none.
-Possible values are ` + "`is`, `startsWith`, `endsWith` and `contains`." + `
-This parameter goes hand in hand with the ` + "`pattern`" + ` parameter. If you’re creating a Rule that depends on a specific query, you must specify the ` + "`pattern` and `anchoring`." + `
+	Possible values are ` + "`is`, `startsWith`, `endsWith` and `contains`." + `
+	This parameter goes hand in hand with the ` + "`pattern`" + ` parameter. If you’re creating a Rule that depends on a specific query, you must specify the ` + "`pattern` and `anchoring`." + `
 
-Otherwise, you can omit both.
-`,
+	Otherwise, you can omit both.
+	`,
 						},
 						"alternatives": {
 							Type:     schema.TypeBool,
@@ -82,13 +89,18 @@ Otherwise, you can omit both.
 							Default:  false,
 							Description: `Whether the ` + "`pattern`" + ` matches on plurals, synonyms, and typos.
 
-This parameter goes hand in hand with the ` + "`pattern` " + ` parameter. If the ` + "`pattern` is “shoe” and `alternatives` is `true`, the `pattern`" + ` matches on “shoes”, as well as synonyms and typos of “shoe”.`,
+	This parameter goes hand in hand with the ` + "`pattern` " + ` parameter. If the ` + "`pattern` is “shoe” and `alternatives` is `true`, the `pattern`" + ` matches on “shoes”, as well as synonyms and typos of “shoe”.`,
 						},
 						"context": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Description: "Rule context (format: `[A-Za-z0-9_-]+`). When specified, the Rule is only applied when the same context is specified at query time (using the `ruleContexts` parameter). When absent, the Rule is generic and always applies (provided that its other conditions are met, of course).",
 						},
+						"filters": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Filters to match against the filters of the query (format: same as the `filters` search parameter, e.g. `brand:apple AND category:phone`). When specified, the Rule is only applied when the query contains the filters. At least one of `pattern`, `context` and `filters` must be set.",
+						},
 					},
 				},
 			},
@@ -97,20 +109,24 @@ This parameter goes hand in hand with the ` + "`pattern` " + ` parameter. If the
 				Required: true,
 				MaxItems: 1,
 				Description: `Consequence of the Rule. 
-At least one of the following object must be used:
-- params
-- promote
-- hide
-- user_data
-`,
+	At least one of the following object must be used:
+	- params
+	- params_json
+	- automatic_facet_filters
+	- automatic_optional_facet_filters
+	- query_remove
+	- promote
+	- hide
+	- user_data
+	`,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"params": {
 							Type:         schema.TypeList,
 							Optional:     true,
 							MaxItems:     1,
-							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
-							Description:  "**Deprecated:** Use `params_json` instead. Additional search parameters. Any valid search parameter is allowed. Specific treatment is applied to these fields: `query`, `automaticFacetFilters`, `automaticOptionalFacetFilters`.",
+							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							Description:  "**Deprecated:** Use `params_json` instead. Additional search parameters. Any valid search parameter is allowed. Specific treatment is applied to these fields: `query`, `automaticFacetFilters`, `automaticOptionalFacetFilters`. The rules using it keep working without any state migration, and switching to `params_json` only shows a one-time diff of the representation.",
 							Deprecated:   "Use `params_json` instead",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
@@ -132,9 +148,9 @@ At least one of the following object must be used:
 													Required:     true,
 													ValidateFunc: validation.StringInSlice([]string{"remove", "replace"}, false),
 													Description: `Type of edit. Must be one of:
-	- ` + "`remove`" + `: when you want to delete some text and not replace it with anything
-	- ` + "`replace`" + `: when you want to delete some text and replace it with something else
-`,
+		- ` + "`remove`" + `: when you want to delete some text and not replace it with anything
+		- ` + "`replace`" + `: when you want to delete some text and replace it with something else
+	`,
 												},
 												"delete": {
 													Type:        schema.TypeString,
@@ -207,15 +223,77 @@ At least one of the following object must be used:
 						"params_json": {
 							Type:             schema.TypeString,
 							Optional:         true,
-							AtLeastOneOf:     []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							AtLeastOneOf:     []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
 							Description:      "Additional search parameters in JSON format. Any valid search parameter is allowed. Specific treatment is applied to these fields: `query`, `automaticFacetFilters`, `automaticOptionalFacetFilters`.",
 							DiffSuppressFunc: diffJsonSuppress,
 							ValidateFunc:     validation.StringIsJSON,
 						},
+						"automatic_facet_filters": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							Description:  "Facets to which automatic filtering must be applied. It's serialized into `automaticFacetFilters` of the consequence params, and can be used together with `params_json` as long as `params_json` doesn't contain `automaticFacetFilters`. Behaves like [facetFilters](https://www.algolia.com/doc/api-reference/api-parameters/facetFilters/).",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"facet": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Attribute to filter on. This must match a facet placeholder in the Rule’s pattern.",
+									},
+									"score": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "Score for the filter. Typically used for optional or disjunctive filters.",
+									},
+									"disjunctive": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Whether the filter is disjunctive (true) or conjunctive (false). If the filter applies multiple times, e.g. because the query string contains multiple values of the same facet, the multiple occurrences are combined with an `AND` operator by default (conjunctive mode). If the filter is specified as disjunctive, however, multiple occurrences are combined with an `OR` operator instead.",
+									},
+								},
+							},
+						},
+						"automatic_optional_facet_filters": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							Description:  "Facets to which automatic optional filtering must be applied. It's serialized into `automaticOptionalFacetFilters` of the consequence params, and can be used together with `params_json` as long as `params_json` doesn't contain `automaticOptionalFacetFilters`. Behaves like [optionalFilters](https://www.algolia.com/doc/api-reference/api-parameters/optionalFilters/).",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"facet": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Attribute to filter on. This must match a facet placeholder in the Rule’s pattern.",
+									},
+									"score": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "Score for the filter. Typically used for optional or disjunctive filters.",
+									},
+									"disjunctive": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Whether the filter is disjunctive (true) or conjunctive (false). If the filter applies multiple times, e.g. because the query string contains multiple values of the same facet, the multiple occurrences are combined with an `AND` operator by default (conjunctive mode). If the filter is specified as disjunctive, however, multiple occurrences are combined with an `OR` operator instead.",
+									},
+								},
+							},
+						},
+						"query_remove": {
+							Type:          schema.TypeList,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							Optional:      true,
+							AtLeastOneOf:  []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							ConflictsWith: []string{"consequence.0.params"},
+							Description:   "Words to remove from the query. It's serialized into `remove` edits of the consequence params `query`, and can be used together with `params_json` as long as `params_json` doesn't contain `query`. Use `query.edits` in `params_json` instead for `replace` edits.",
+						},
 						"promote": {
 							Type:         schema.TypeList,
 							Optional:     true,
-							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
 							Description:  "Objects to promote as hits.",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
@@ -233,19 +311,27 @@ At least one of the following object must be used:
 								},
 							},
 						},
+						"filter_promotes": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Whether the promoted objects must match the filters of the query to be promoted. Defaults to false.",
+						},
 						"hide": {
 							Type:         schema.TypeSet,
 							Elem:         &schema.Schema{Type: schema.TypeString},
 							Set:          schema.HashString,
 							Optional:     true,
-							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
 							Description:  "List of object IDs to hide from hits.",
 						},
 						"user_data": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							AtLeastOneOf: []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
-							Description:  "Custom JSON formatted string that will be appended to the userData array in the response. This object is not interpreted by the API. It is limited to 1kB of minified JSON.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							AtLeastOneOf:     []string{"consequence.0.params", "consequence.0.params_json", "consequence.0.automatic_facet_filters", "consequence.0.automatic_optional_facet_filters", "consequence.0.query_remove", "consequence.0.promote", "consequence.0.hide", "consequence.0.user_data"},
+							Description:      "Custom JSON formatted string that will be appended to the userData array in the response. This object is not interpreted by the API. It is limited to 1kB of minified JSON.",
+							DiffSuppressFunc: diffJsonSuppress,
+							ValidateFunc:     validation.StringIsJSON,
 						},
 					},
 				},
@@ -264,7 +350,7 @@ At least one of the following object must be used:
 			"validity": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Objects to promote as hits.",
+				Description: "Time ranges when the Rule is active. The ranges are sent to Algolia in chronological order, so the order in the configuration doesn't matter.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"from": {
@@ -282,6 +368,18 @@ At least one of the following object must be used:
 					},
 				},
 			},
+			"reject_unknown_params": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to fail the plan with an error when `consequence.params_json` contains keys which are not known search parameters (e.g. `facetFilter` instead of `facetFilters`). Unknown parameters are dropped by the provider and never sent to Algolia, so they silently have no effect when it's disabled.",
+			},
+			"forward_to_replicas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to forward the Rule to the replicas of the index, on save and delete. It isn't stored in Algolia, so it's set to `false` on import and only affects the subsequent applies.",
+			},
 		},
 	}
 }
@@ -295,12 +393,16 @@ func resourceRuleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
-	res, err := index.SaveRule(rule, ctx)
+	forwardToReplicas := opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool))
+	err = retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		res, err := index.SaveRule(rule, forwardToReplicas, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(rule.ObjectID)
@@ -324,12 +426,16 @@ func resourceRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
-	res, err := index.SaveRule(rule, ctx)
+	forwardToReplicas := opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool))
+	err = retryWrite(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
+		res, err := index.SaveRule(rule, forwardToReplicas, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(rule.ObjectID)
@@ -341,12 +447,12 @@ func resourceRuleDelete(ctx context.Context, d *schema.ResourceData, m interface
 	apiClient := m.(*apiClient)
 
 	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
-	res, err := index.DeleteRule(d.Get("object_id").(string), ctx)
+	res, err := index.DeleteRule(d.Get("object_id").(string), opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool)), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
@@ -364,6 +470,13 @@ func resourceRuleStateContext(ctx context.Context, d *schema.ResourceData, m int
 	if err := d.Set("index_name", indexName); err != nil {
 		return nil, err
 	}
+	// reject_unknown_params and forward_to_replicas aren't stored in Algolia, so the defaults are set.
+	if err := d.Set("reject_unknown_params", false); err != nil {
+		return nil, err
+	}
+	if err := d.Set("forward_to_replicas", false); err != nil {
+		return nil, err
+	}
 
 	if err := refreshRuleState(ctx, d, m); err != nil {
 		return nil, err
@@ -398,33 +511,62 @@ func refreshRuleState(ctx context.Context, d *schema.ResourceData, m interface{}
 			d.SetId("")
 			return nil
 		}
-		return err
+		return algoliautil.HumanizeError(err)
 	}
 
-	var conditions []interface{}
-	for _, c := range rule.Conditions {
-		// The code below is workaround since Alternatives.enable is a private field.
-		alternativesJSONBytes, _ := c.Alternatives.MarshalJSON()
-		alternatives, _ := strconv.ParseBool(string(alternativesJSONBytes))
-		conditions = append(conditions, map[string]interface{}{
-			"pattern":      c.Pattern,
-			"anchoring":    c.Anchoring,
-			"alternatives": alternatives,
-			"context":      c.Context,
-		})
+	// The deprecated `params` is written only when it's used instead of `params_json`.
+	values, err := mapToRuleValues(d, indexName, rule, !isConsequenceBlockSet(d, "params"))
+	if err != nil {
+		return err
 	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	d.SetId(rule.ObjectID)
+
+	return nil
+}
 
+// mapToRuleValues maps the rule to the values of the state. It's shared by the resource and the data source.
+// The consequence params are marshalled into `params_json` when paramsAsJSON is true, otherwise into `params`.
+func mapToRuleValues(d *schema.ResourceData, indexName string, rule search.Rule, paramsAsJSON bool) (map[string]interface{}, error) {
 	consequence := map[string]interface{}{}
 	{
 		if rule.Consequence.Params != nil {
-			if isParamsJSONSet(d) {
-				paramsJSON, err := json.Marshal(rule.Consequence.Params)
+			params := *rule.Consequence.Params
+			isStructuredParamsSet := false
+			if isConsequenceBlockSet(d, "automatic_facet_filters") {
+				consequence["automatic_facet_filters"] = flattenAutomaticFacetFilters(params.AutomaticFacetFilters)
+				params.AutomaticFacetFilters = nil
+				isStructuredParamsSet = true
+			}
+			if isConsequenceBlockSet(d, "automatic_optional_facet_filters") {
+				consequence["automatic_optional_facet_filters"] = flattenAutomaticFacetFilters(params.AutomaticOptionalFacetFilters)
+				params.AutomaticOptionalFacetFilters = nil
+				isStructuredParamsSet = true
+			}
+			if isConsequenceBlockSet(d, "query_remove") {
+				if words, ok := flattenQueryRemove(params.Query); ok {
+					consequence["query_remove"] = words
+					params.Query = nil
+					isStructuredParamsSet = true
+				}
+			}
+			if paramsAsJSON {
+				paramsJSON, err := marshalRuleParams(params)
 				if err != nil {
-					return fmt.Errorf("failed to marshal consequence params: %w", err)
+					return nil, err
+				}
+				// The configured params_json is kept as is if it's equivalent, since the filters can be written in multiple forms.
+				if configured, ok := d.Get("consequence.0.params_json").(string); ok && isRuleParamsJSONEquivalent(configured, paramsJSON) {
+					paramsJSON = configured
+				}
+				// params_json can be empty when all the params are configured by the structured blocks.
+				if !isStructuredParamsSet || paramsJSON != "{}" {
+					consequence["params_json"] = paramsJSON
 				}
-				consequence["params_json"] = string(paramsJSON)
 			} else {
-				params := rule.Consequence.Params
 				paramsData := map[string]interface{}{}
 				if params.Query != nil {
 					simpleQuery, objectQuery := params.Query.Get()
@@ -442,89 +584,87 @@ func refreshRuleState(ctx context.Context, d *schema.ResourceData, m interface{}
 						paramsData["query"] = simpleQuery
 					}
 				}
-				var automaticFacetFilters []interface{}
-				for _, aff := range params.AutomaticFacetFilters {
-					automaticFacetFilters = append(automaticFacetFilters, map[string]interface{}{
-						"facet":       aff.Facet,
-						"score":       aff.Score,
-						"disjunctive": aff.Disjunctive,
-					})
-				}
-				paramsData["automatic_facet_filters"] = automaticFacetFilters
-
-				var automaticOptionalFacetFilters []interface{}
-				for _, aff := range params.AutomaticOptionalFacetFilters {
-					automaticOptionalFacetFilters = append(automaticOptionalFacetFilters, map[string]interface{}{
-						"facet":       aff.Facet,
-						"score":       aff.Score,
-						"disjunctive": aff.Disjunctive,
-					})
-				}
-				paramsData["automatic_optional_facet_filters"] = automaticOptionalFacetFilters
+				paramsData["automatic_facet_filters"] = flattenAutomaticFacetFilters(params.AutomaticFacetFilters)
+				paramsData["automatic_optional_facet_filters"] = flattenAutomaticFacetFilters(params.AutomaticOptionalFacetFilters)
 
 				consequence["params"] = []interface{}{paramsData}
 			}
 		}
-		var promotedObjects []interface{}
-		for _, p := range rule.Consequence.Promote {
-			promotedObject := map[string]interface{}{}
-			if p.ObjectID != "" {
-				promotedObject["object_ids"] = []string{p.ObjectID}
-			}
-			if len(p.ObjectIDs) > 0 {
-				promotedObject["object_ids"] = p.ObjectIDs
-			}
-			promotedObject["position"] = p.Position
-			promotedObjects = append(promotedObjects, promotedObject)
-		}
-		consequence["promote"] = promotedObjects
-
-		var hiddenObjectIDs []string
-		for _, hiddenObject := range rule.Consequence.Hide {
-			hiddenObjectIDs = append(hiddenObjectIDs, hiddenObject.ObjectID)
-		}
-		consequence["hide"] = hiddenObjectIDs
-
-		if rule.Consequence.UserData != nil {
-			consequence["user_data"] = rule.Consequence.UserData
+		if err := flattenRuleConsequenceObjects(rule.Consequence, consequence); err != nil {
+			return nil, err
 		}
 	}
 
-	var validty []interface{}
-	for _, timeRange := range rule.Validity {
-		validty = append(validty, map[string]string{
-			"from":  timeRange.From.In(time.UTC).Format(time.RFC3339),
-			"until": timeRange.Until.In(time.UTC).Format(time.RFC3339),
-		})
-	}
-
 	values := map[string]interface{}{
 		"index_name":  indexName,
 		"object_id":   rule.ObjectID,
-		"conditions":  conditions,
+		"conditions":  flattenRuleConditions(rule.Conditions),
 		"consequence": []interface{}{consequence},
 		"description": rule.Description,
 		"enabled":     rule.Enabled.Get(),
-		"validity":    validty,
-	}
-	if err := setValues(d, values); err != nil {
-		return err
+		"validity":    flattenValidity(rule.Validity, d.Get("validity")),
 	}
+	return values, nil
+}
 
-	d.SetId(rule.ObjectID)
+// flattenRuleConditions converts the conditions of the rule to the resource data.
+func flattenRuleConditions(ruleConditions []search.RuleCondition) []interface{} {
+	var conditions []interface{}
+	for _, c := range ruleConditions {
+		conditions = append(conditions, map[string]interface{}{
+			"pattern":      c.Pattern,
+			"anchoring":    c.Anchoring,
+			"alternatives": isAlternativesEnabled(c.Alternatives),
+			"context":      c.Context,
+			"filters":      c.Filters,
+		})
+	}
+	return conditions
+}
 
-	return nil
+// isAlternativesEnabled returns whether the alternatives of the condition are enabled. The flag of search.Alternatives
+// is private, so it's compared with the enabled one. Alternatives which aren't returned by Algolia are disabled by default.
+func isAlternativesEnabled(alternatives *search.Alternatives) bool {
+	return alternatives != nil && *alternatives == *search.AlternativesEnabled()
 }
 
-func isParamsJSONSet(d *schema.ResourceData) bool {
-	l, ok := d.Get("consequence").([]interface{})
-	if !ok || len(l) == 0 {
-		return false
+// flattenRuleConsequenceObjects sets promote, filter_promotes, hide and user_data of the rule consequence to the consequence data.
+func flattenRuleConsequenceObjects(ruleConsequence search.RuleConsequence, consequence map[string]interface{}) error {
+	var promotedObjects []interface{}
+	for _, p := range ruleConsequence.Promote {
+		promotedObject := map[string]interface{}{}
+		if p.ObjectID != "" {
+			promotedObject["object_ids"] = []string{p.ObjectID}
+		}
+		if len(p.ObjectIDs) > 0 {
+			promotedObject["object_ids"] = p.ObjectIDs
+		}
+		promotedObject["position"] = p.Position
+		promotedObjects = append(promotedObjects, promotedObject)
+	}
+	consequence["promote"] = promotedObjects
+	consequence["filter_promotes"] = ruleConsequence.FilterPromotes.Get()
+
+	var hiddenObjectIDs []string
+	for _, hiddenObject := range ruleConsequence.Hide {
+		hiddenObjectIDs = append(hiddenObjectIDs, hiddenObject.ObjectID)
 	}
+	consequence["hide"] = hiddenObjectIDs
 
-	consequence := l[0].(map[string]interface{})
-	_, ok = consequence["params_json"]
-	return ok
+	if ruleConsequence.UserData != nil {
+		userData, err := json.Marshal(ruleConsequence.UserData)
+		if err != nil {
+			return fmt.Errorf("failed to marshal user_data: %w", err)
+		}
+		consequence["user_data"] = string(userData)
+	}
+	return nil
+}
+
+// isConsequenceBlockSet returns whether the given block in consequence is configured.
+func isConsequenceBlockSet(d *schema.ResourceData, key string) bool {
+	l, ok := d.Get(fmt.Sprintf("consequence.0.%s", key)).([]interface{})
+	return ok && len(l) > 0
 }
 
 func mapToRule(d *schema.ResourceData) (search.Rule, error) {
@@ -540,6 +680,10 @@ func mapToRule(d *schema.ResourceData) (search.Rule, error) {
 	if err != nil {
 		return rule, err
 	}
+	// AtLeastOneOf doesn't catch the blocks set with empty values, e.g. `params_json = "{}"`.
+	if err := validateRuleConsequenceNotEmpty(rule); err != nil {
+		return rule, err
+	}
 	if v, ok := d.GetOk("description"); ok {
 		rule.Description = v.(string)
 	}
@@ -572,6 +716,9 @@ func unmarshalConditions(configured interface{}, rule *search.Rule) {
 		if v, ok := c["context"]; ok {
 			ruleCondition.Context = v.(string)
 		}
+		if v, ok := c["filters"]; ok {
+			ruleCondition.Filters = v.(string)
+		}
 		if v, ok := c["alternatives"]; ok {
 			if v.(bool) {
 				ruleCondition.Alternatives = search.AlternativesEnabled()
@@ -596,13 +743,44 @@ func unmarshalConsequence(configured interface{}) (search.RuleConsequence, error
 	if v, ok := config["params"]; ok {
 		consequence.Params = unmarshalConsequenceParams(v)
 	}
-	if v, ok := config["params_json"]; ok {
+	if v, ok := config["params_json"]; ok && v.(string) != "" {
 		var err error
 		consequence.Params, err = unmarshalConsequenceParamsJSON(v)
 		if err != nil {
 			return search.RuleConsequence{}, err
 		}
 	}
+	if v, ok := config["automatic_facet_filters"]; ok && len(v.([]interface{})) > 0 {
+		if consequence.Params == nil {
+			consequence.Params = &search.RuleParams{}
+		}
+		if len(consequence.Params.AutomaticFacetFilters) > 0 {
+			return search.RuleConsequence{}, errors.New("automaticFacetFilters can't be set in both `params_json` and `automatic_facet_filters`")
+		}
+		consequence.Params.AutomaticFacetFilters = unmarshalAutomaticFacetFilters(v)
+	}
+	if v, ok := config["automatic_optional_facet_filters"]; ok && len(v.([]interface{})) > 0 {
+		if consequence.Params == nil {
+			consequence.Params = &search.RuleParams{}
+		}
+		if len(consequence.Params.AutomaticOptionalFacetFilters) > 0 {
+			return search.RuleConsequence{}, errors.New("automaticOptionalFacetFilters can't be set in both `params_json` and `automatic_optional_facet_filters`")
+		}
+		consequence.Params.AutomaticOptionalFacetFilters = unmarshalAutomaticFacetFilters(v)
+	}
+	if v, ok := config["query_remove"]; ok && len(v.([]interface{})) > 0 {
+		if consequence.Params == nil {
+			consequence.Params = &search.RuleParams{}
+		}
+		if consequence.Params.Query != nil {
+			return search.RuleConsequence{}, errors.New("query can't be set in both `params_json` and `query_remove`")
+		}
+		var edits []search.QueryEdit
+		for _, word := range castStringList(v) {
+			edits = append(edits, search.RemoveEdit(word))
+		}
+		consequence.Params.Query = search.NewRuleQueryObject(search.RuleQueryObjectQuery{Edits: edits})
+	}
 	if v, ok := config["promote"]; ok {
 		var promotedObjects []search.PromotedObject
 		for _, v := range v.([]interface{}) {
@@ -615,6 +793,9 @@ func unmarshalConsequence(configured interface{}) (search.RuleConsequence, error
 		}
 		consequence.Promote = promotedObjects
 	}
+	if v, ok := config["filter_promotes"]; ok && v.(bool) {
+		consequence.FilterPromotes = opt.FilterPromotes(true)
+	}
 	if v, ok := config["hide"]; ok {
 		var hide []search.HiddenObject
 		for _, objectID := range castStringSet(v) {
@@ -622,13 +803,43 @@ func unmarshalConsequence(configured interface{}) (search.RuleConsequence, error
 		}
 		consequence.Hide = hide
 	}
-	if v, ok := config["user_data"]; ok {
-		consequence.UserData = v.(string)
+	if v, ok := config["user_data"]; ok && v.(string) != "" {
+		if err := json.Unmarshal([]byte(v.(string)), &consequence.UserData); err != nil {
+			return search.RuleConsequence{}, fmt.Errorf("failed to unmarshal user_data: %w", err)
+		}
 	}
-	consequence.UserData = nil
 	return consequence, nil
 }
 
+// validateRuleConsequenceNotEmpty returns an error if the consequence of the rule is empty.
+func validateRuleConsequenceNotEmpty(rule search.Rule) error {
+	isEmpty, err := isRuleConsequenceEmpty(rule.Consequence)
+	if err != nil {
+		return err
+	}
+	if isEmpty {
+		return fmt.Errorf("consequence of rule (%s) is empty. At least one of params, params_json, query_remove, automatic_facet_filters, automatic_optional_facet_filters, promote, hide or user_data must be set with a non-empty value, filter_promotes alone doesn't count", rule.ObjectID)
+	}
+	return nil
+}
+
+// isRuleConsequenceEmpty returns whether the consequence has none of params, promote, hide and user_data, which Algolia rejects.
+// params_json, query_remove, automatic_facet_filters and automatic_optional_facet_filters are all merged into params,
+// and filter_promotes alone changes nothing.
+func isRuleConsequenceEmpty(consequence search.RuleConsequence) (bool, error) {
+	if len(consequence.Promote) > 0 || len(consequence.Hide) > 0 || consequence.UserData != nil {
+		return false, nil
+	}
+	if consequence.Params == nil {
+		return true, nil
+	}
+	paramsJSON, err := marshalRuleParams(*consequence.Params)
+	if err != nil {
+		return false, err
+	}
+	return paramsJSON == "{}", nil
+}
+
 func unmarshalConsequenceParams(configured interface{}) *search.RuleParams {
 	l := configured.([]interface{})
 	if len(l) == 0 || l[0] == nil {
@@ -675,6 +886,129 @@ func unmarshalConsequenceParamsJSON(configured interface{}) (*search.RuleParams,
 	return &params, nil
 }
 
+// ruleFilterParams are the consequence params of the filters which can be nested to combine the filters with `OR`.
+var ruleFilterParams = []string{"facetFilters", "optionalFilters", "numericFilters", "tagFilters"}
+
+// marshalRuleParams marshals the consequence params to JSON. The API client always marshals the filters as nested arrays
+// (e.g. `["brand:apple"]` => `[["brand:apple"]]`), so the single filter groups are flattened back to keep them single-level.
+func marshalRuleParams(params search.RuleParams) (string, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal consequence params: %w", err)
+	}
+	var paramsMap map[string]interface{}
+	if err := json.Unmarshal(b, &paramsMap); err != nil {
+		return "", fmt.Errorf("failed to marshal consequence params: %w", err)
+	}
+	for _, key := range ruleFilterParams {
+		if filters, ok := paramsMap[key].([]interface{}); ok {
+			paramsMap[key] = flattenSingleFilterGroups(filters)
+		}
+	}
+	b, err = json.Marshal(paramsMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal consequence params: %w", err)
+	}
+	return string(b), nil
+}
+
+// flattenSingleFilterGroups replaces the filter groups with a single filter by the filter itself,
+// e.g. `[["brand:apple"], ["color:red", "color:blue"]]` => `["brand:apple", ["color:red", "color:blue"]]`.
+func flattenSingleFilterGroups(filters []interface{}) []interface{} {
+	flattened := make([]interface{}, 0, len(filters))
+	for _, filter := range filters {
+		if group, ok := filter.([]interface{}); ok && len(group) == 1 {
+			flattened = append(flattened, group[0])
+			continue
+		}
+		flattened = append(flattened, filter)
+	}
+	return flattened
+}
+
+// isRuleParamsJSONEquivalent returns whether the configured params_json results in the same params as paramsJSON.
+func isRuleParamsJSONEquivalent(configured string, paramsJSON string) bool {
+	if configured == "" {
+		return false
+	}
+	params, err := unmarshalConsequenceParamsJSON(configured)
+	if err != nil {
+		return false
+	}
+	normalized, err := marshalRuleParams(*params)
+	if err != nil {
+		return false
+	}
+	equal, _ := jsonBytesEqual([]byte(normalized), []byte(paramsJSON))
+	return equal
+}
+
+// validateRuleConditionsNotEmpty returns an error if a condition has none of `pattern`, `context` and `filters`, which Algolia rejects.
+func validateRuleConditionsNotEmpty(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("conditions") {
+		return nil
+	}
+	for i, v := range d.Get("conditions").([]interface{}) {
+		condition, _ := v.(map[string]interface{})
+		if condition == nil || (condition["pattern"] == "" && condition["context"] == "" && condition["filters"] == "") {
+			return fmt.Errorf("`conditions.%d` must have at least one of `pattern`, `context` and `filters`", i)
+		}
+	}
+	return nil
+}
+
+// validateKnownConsequenceParams rejects unknown search parameters in `params_json` if `reject_unknown_params` is enabled.
+// The unknown parameters are dropped when params_json is decoded into search.RuleParams, so typos like `facetFilter`
+// would make the rule silently have no effect.
+func validateKnownConsequenceParams(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("reject_unknown_params").(bool) || !d.NewValueKnown("consequence.0.params_json") {
+		return nil
+	}
+	paramsJSON := d.Get("consequence.0.params_json").(string)
+	if paramsJSON == "" {
+		return nil
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+		// invalid JSON is reported by the validation of params_json.
+		return nil
+	}
+	if unknownParams := findUnknownRuleParams(params); len(unknownParams) > 0 {
+		return fmt.Errorf("`params_json` of rule (%s) contains unknown search parameters (%s), which are never sent to Algolia", d.Get("object_id").(string), strings.Join(unknownParams, ", "))
+	}
+	return nil
+}
+
+// findUnknownRuleParams returns the sorted keys of params which aren't known as the consequence params.
+// The known params are taken from the JSON fields of search.RuleParams to keep them in sync with the API client.
+func findUnknownRuleParams(params map[string]interface{}) []string {
+	knownParams := map[string]bool{}
+	collectJSONFieldNames(reflect.TypeOf(search.RuleParams{}), knownParams)
+
+	var unknownParams []string
+	for k := range params {
+		if !knownParams[k] {
+			unknownParams = append(unknownParams, k)
+		}
+	}
+	sort.Strings(unknownParams)
+	return unknownParams
+}
+
+// collectJSONFieldNames collects the JSON field names of the struct type including the ones of embedded structs.
+func collectJSONFieldNames(t reflect.Type, names map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectJSONFieldNames(field.Type, names)
+			continue
+		}
+		if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+}
+
 func unmarshalAutomaticFacetFilters(configured interface{}) []search.AutomaticFacetFilter {
 	var automaticFacetFilters []search.AutomaticFacetFilter
 	for _, v := range configured.([]interface{}) {
@@ -689,6 +1023,37 @@ func unmarshalAutomaticFacetFilters(configured interface{}) []search.AutomaticFa
 	return automaticFacetFilters
 }
 
+func flattenAutomaticFacetFilters(automaticFacetFilters []search.AutomaticFacetFilter) []interface{} {
+	var flattened []interface{}
+	for _, aff := range automaticFacetFilters {
+		flattened = append(flattened, map[string]interface{}{
+			"facet":       aff.Facet,
+			"score":       aff.Score,
+			"disjunctive": aff.Disjunctive,
+		})
+	}
+	return flattened
+}
+
+// flattenQueryRemove returns the removed words if the query consists only of remove edits.
+func flattenQueryRemove(query *search.RuleQuery) ([]string, bool) {
+	if query == nil {
+		return nil, false
+	}
+	_, objectQuery := query.Get()
+	if objectQuery == nil || len(objectQuery.Edits) == 0 {
+		return nil, false
+	}
+	var words []string
+	for _, edit := range objectQuery.Edits {
+		if edit.Type != search.Remove {
+			return nil, false
+		}
+		words = append(words, edit.Delete)
+	}
+	return words, true
+}
+
 func unmarshalValidity(configured interface{}) []search.TimeRange {
 	l := configured.([]interface{})
 	if len(l) == 0 || l[0] == nil {
@@ -705,6 +1070,60 @@ func unmarshalValidity(configured interface{}) []search.TimeRange {
 			Until: until,
 		})
 	}
+	sortTimeRanges(timeRanges)
 
 	return timeRanges
 }
+
+// flattenValidity converts the time ranges to the resource data. The ranges are in the order of the configured ones,
+// and the configured values are kept as they are if they represent the same time, so that neither the order
+// nor the time zone of the configuration causes a diff. The other ranges follow in chronological order.
+func flattenValidity(timeRanges []search.TimeRange, configured interface{}) []interface{} {
+	remaining := make([]search.TimeRange, len(timeRanges))
+	copy(remaining, timeRanges)
+	sortTimeRanges(remaining)
+
+	var validity []interface{}
+	configuredRanges, _ := configured.([]interface{})
+	for _, v := range configuredRanges {
+		timeRangeData, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		from, err := time.Parse(time.RFC3339, timeRangeData["from"].(string))
+		if err != nil {
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, timeRangeData["until"].(string))
+		if err != nil {
+			continue
+		}
+		for i, timeRange := range remaining {
+			if timeRange.From.Equal(from) && timeRange.Until.Equal(until) {
+				validity = append(validity, map[string]interface{}{
+					"from":  timeRangeData["from"],
+					"until": timeRangeData["until"],
+				})
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, timeRange := range remaining {
+		validity = append(validity, map[string]interface{}{
+			"from":  timeRange.From.In(time.UTC).Format(time.RFC3339),
+			"until": timeRange.Until.In(time.UTC).Format(time.RFC3339),
+		})
+	}
+	return validity
+}
+
+// sortTimeRanges sorts the time ranges by `from`, then by `until`.
+func sortTimeRanges(timeRanges []search.TimeRange) {
+	sort.SliceStable(timeRanges, func(i, j int) bool {
+		if !timeRanges[i].From.Equal(timeRanges[j].From) {
+			return timeRanges[i].From.Before(timeRanges[j].From)
+		}
+		return timeRanges[i].Until.Before(timeRanges[j].Until)
+	})
+}
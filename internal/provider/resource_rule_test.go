@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -55,6 +63,1058 @@ func TestAccResourceRule(t *testing.T) {
 	})
 }
 
+func TestResourceRule_conditionsMaxItems(t *testing.T) {
+	t.Parallel()
+
+	conditions := make([]interface{}, 0, 26)
+	for i := 0; i < 26; i++ {
+		conditions = append(conditions, map[string]interface{}{"pattern": fmt.Sprintf("pattern%d", i), "anchoring": "is"})
+	}
+	raw := map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "test",
+		"conditions":  conditions,
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"test"}`}},
+	}
+	diags := resourceRule().Validate(terraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() {
+		t.Fatal("Validate() error = nil, want max items error")
+	}
+	if !regexp.MustCompile("conditions supports 25 item maximum").MatchString(fmt.Sprintf("%v", diags)) {
+		t.Errorf("Validate() error = %v, want max items error", diags)
+	}
+}
+
+func TestResourceRule_validateRuleConditionsNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition map[string]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "pattern",
+			condition: map[string]interface{}{"pattern": "shoes", "anchoring": "contains"},
+			wantErr:   false,
+		},
+		{
+			name:      "filters only",
+			condition: map[string]interface{}{"filters": "brand:apple"},
+			wantErr:   false,
+		},
+		{
+			name:      "context only",
+			condition: map[string]interface{}{"context": "summer"},
+			wantErr:   false,
+		},
+		{
+			name:      "alternatives only",
+			condition: map[string]interface{}{"alternatives": true},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "test",
+				"conditions":  []interface{}{tt.condition},
+				"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"test"}`}},
+			}
+			_, err := testResourceDiff(resourceRule(), raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "`conditions.0` must have at least one of `pattern`, `context` and `filters`") {
+				t.Errorf("Diff() error = %v, want empty condition error", err)
+			}
+		})
+	}
+}
+
+func TestResourceRule_filtersConditionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var savedRule []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/apple":
+			savedRule, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"apple"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/apple":
+			_, _ = w.Write(savedRule)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "apple",
+		"conditions":  []interface{}{map[string]interface{}{"filters": "brand:apple"}},
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone"}`}},
+	})
+
+	if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleCreate() error = %v", diags)
+	}
+	if !strings.Contains(string(savedRule), `"filters":"brand:apple"`) {
+		t.Errorf("saved rule = %s, want filters condition", savedRule)
+	}
+	if got := d.Get("conditions.0.filters").(string); got != "brand:apple" {
+		t.Errorf("conditions.0.filters = %v, want brand:apple", got)
+	}
+}
+
+func TestResourceRule_createRetriesTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	var saveRuleAttempts int
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/transient":
+			saveRuleAttempts++
+			// all the 4 hosts fail on the first attempt of the write.
+			if saveRuleAttempts <= 4 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"transient"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/transient":
+			_, _ = w.Write([]byte(`{"objectID":"transient","consequence":{"params":{"query":"shoes"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "transient",
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"shoes"}`}},
+	})
+
+	if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleCreate() error = %v", diags)
+	}
+	if saveRuleAttempts != 5 {
+		t.Errorf("save rule attempts = %v, want %v", saveRuleAttempts, 5)
+	}
+	if got := d.Id(); got != "transient" {
+		t.Errorf("id = %v, want transient", got)
+	}
+}
+
+func TestResourceRule_forwardToReplicas(t *testing.T) {
+	t.Parallel()
+
+	var forwardToReplicas []string
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/forwarded":
+			forwardToReplicas = append(forwardToReplicas, r.URL.Query().Get("forwardToReplicas"))
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"forwarded"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/test/rules/forwarded":
+			forwardToReplicas = append(forwardToReplicas, r.URL.Query().Get("forwardToReplicas"))
+			_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/forwarded":
+			_, _ = w.Write([]byte(`{"objectID":"forwarded","consequence":{"params":{"query":"shoes"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":          "test",
+		"object_id":           "forwarded",
+		"consequence":         []interface{}{map[string]interface{}{"params_json": `{"query":"shoes"}`}},
+		"forward_to_replicas": true,
+	})
+
+	if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleCreate() error = %v", diags)
+	}
+	// forward_to_replicas isn't returned by Algolia, so it must be kept as configured.
+	if got := d.Get("forward_to_replicas").(bool); !got {
+		t.Errorf("forward_to_replicas = %v, want true", got)
+	}
+	if diags := resourceRuleDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleDelete() error = %v", diags)
+	}
+	if want := []string{"true", "true"}; !reflect.DeepEqual(forwardToReplicas, want) {
+		t.Errorf("forwardToReplicas of requests = %v, want %v", forwardToReplicas, want)
+	}
+}
+
+func TestResourceRule_importForwardToReplicas(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/forwarded":
+			_, _ = w.Write([]byte(`{"objectID":"forwarded","consequence":{"params":{"query":"shoes"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := resourceRule().Data(nil)
+	d.SetId("test/forwarded")
+	if _, err := resourceRuleStateContext(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("resourceRuleStateContext() error = %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		forwardToReplicas bool
+		wantDiff          []string
+	}{
+		{
+			name:              "default",
+			forwardToReplicas: false,
+			wantDiff:          nil,
+		},
+		{
+			// The forwarding intent can't be imported, so it's applied on the next apply.
+			name:              "forwarded",
+			forwardToReplicas: true,
+			wantDiff:          []string{"forward_to_replicas"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"index_name":          "test",
+				"object_id":           "forwarded",
+				"consequence":         []interface{}{map[string]interface{}{"params_json": `{"query":"shoes"}`}},
+				"forward_to_replicas": tt.forwardToReplicas,
+			}
+			diff, err := resourceRule().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), apiClient)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			var gotDiff []string
+			if diff != nil {
+				for k := range diff.Attributes {
+					gotDiff = append(gotDiff, k)
+				}
+			}
+			if !reflect.DeepEqual(gotDiff, tt.wantDiff) {
+				t.Errorf("Diff() attributes = %v, want %v", gotDiff, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestResourceRule_filterPromotes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		consequence        map[string]interface{}
+		wantFilterPromotes bool
+	}{
+		{
+			name: "filter promotes",
+			consequence: map[string]interface{}{
+				"promote":         []interface{}{map[string]interface{}{"object_ids": []interface{}{"123"}, "position": 0}},
+				"filter_promotes": true,
+			},
+			wantFilterPromotes: true,
+		},
+		{
+			name: "not configured",
+			consequence: map[string]interface{}{
+				"promote": []interface{}{map[string]interface{}{"object_ids": []interface{}{"123"}, "position": 0}},
+			},
+			wantFilterPromotes: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var savedRule []byte
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/promote":
+					savedRule, _ = io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"promote"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+					_, _ = w.Write([]byte(`{"status":"published"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/promote":
+					_, _ = w.Write(savedRule)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			raw := map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "promote",
+				"consequence": []interface{}{tt.consequence},
+			}
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, raw)
+
+			if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceRuleCreate() error = %v", diags)
+			}
+			var rule struct {
+				Consequence struct {
+					FilterPromotes *bool `json:"filterPromotes"`
+				} `json:"consequence"`
+			}
+			if err := json.Unmarshal(savedRule, &rule); err != nil {
+				t.Fatal(err)
+			}
+			if got := rule.Consequence.FilterPromotes != nil && *rule.Consequence.FilterPromotes; got != tt.wantFilterPromotes {
+				t.Errorf("filterPromotes of saved rule = %v, want %v", got, tt.wantFilterPromotes)
+			}
+			if got := d.Get("consequence.0.filter_promotes").(bool); got != tt.wantFilterPromotes {
+				t.Errorf("filter_promotes = %v, want %v", got, tt.wantFilterPromotes)
+			}
+
+			// the rules without filter_promotes must not show a diff.
+			diff, err := resourceRule().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), apiClient)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if diff != nil && !diff.Empty() {
+				t.Errorf("Diff() = %v, want empty", diff)
+			}
+		})
+	}
+}
+
+func TestResourceRule_readMultipleValidityRanges(t *testing.T) {
+	t.Parallel()
+
+	// 2030-01-01T00:00:00Z - 2030-01-02T00:00:00Z and 2030-02-01T00:00:00Z - 2030-02-02T00:00:00Z
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/sale":
+			_, _ = w.Write([]byte(`{"objectID":"sale","consequence":{"params":{"query":"sale"}},"validity":[
+				{"from":1893456000,"until":1893542400},
+				{"from":1896134400,"until":1896220800}
+			]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		validity []interface{}
+		want     []interface{}
+	}{
+		{
+			name: "configured in reverse chronological order with time zone",
+			validity: []interface{}{
+				map[string]interface{}{"from": "2030-02-01T09:00:00+09:00", "until": "2030-02-02T09:00:00+09:00"},
+				map[string]interface{}{"from": "2030-01-01T00:00:00Z", "until": "2030-01-02T00:00:00Z"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"from": "2030-02-01T09:00:00+09:00", "until": "2030-02-02T09:00:00+09:00"},
+				map[string]interface{}{"from": "2030-01-01T00:00:00Z", "until": "2030-01-02T00:00:00Z"},
+			},
+		},
+		{
+			name: "not configured",
+			want: []interface{}{
+				map[string]interface{}{"from": "2030-01-01T00:00:00Z", "until": "2030-01-02T00:00:00Z"},
+				map[string]interface{}{"from": "2030-02-01T00:00:00Z", "until": "2030-02-02T00:00:00Z"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "sale",
+				"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"sale"}`}},
+				"validity":    tt.validity,
+			})
+			d.SetId("sale")
+
+			if diags := resourceRuleRead(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceRuleRead() error = %v", diags)
+			}
+			if got := d.Get("validity").([]interface{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mapToRule_validitySorted(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"object_id":   "sale",
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"sale"}`}},
+		"validity": []interface{}{
+			map[string]interface{}{"from": "2030-02-01T09:00:00+09:00", "until": "2030-02-02T09:00:00+09:00"},
+			map[string]interface{}{"from": "2030-01-01T00:00:00Z", "until": "2030-01-02T00:00:00Z"},
+		},
+	})
+
+	rule, err := mapToRule(d)
+	if err != nil {
+		t.Fatalf("mapToRule() error = %v", err)
+	}
+	if len(rule.Validity) != 2 || !rule.Validity[0].From.Before(rule.Validity[1].From) {
+		t.Errorf("validity = %v, want chronological order", rule.Validity)
+	}
+}
+
+func TestResourceRule_facetFiltersRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		paramsJSON string
+	}{
+		{
+			name:       "single-level facet filters",
+			paramsJSON: `{"facetFilters":["brand_name:-Laser"]}`,
+		},
+		{
+			name:       "nested facet filters",
+			paramsJSON: `{"facetFilters":[["brand_name:-Laser"]]}`,
+		},
+		{
+			name:       "mixed filters",
+			paramsJSON: `{"optionalFilters":["brand:apple",["color:red","color:blue"]],"numericFilters":["price<100"]}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var savedRule []byte
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/filters":
+					savedRule, _ = io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"filters"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+					_, _ = w.Write([]byte(`{"status":"published"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/filters":
+					_, _ = w.Write(savedRule)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "filters",
+				"consequence": []interface{}{map[string]interface{}{"params_json": tt.paramsJSON}},
+			})
+
+			if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceRuleCreate() error = %v", diags)
+			}
+			if got := d.Get("consequence.0.params_json").(string); got != tt.paramsJSON {
+				t.Errorf("params_json = %v, want %v", got, tt.paramsJSON)
+			}
+		})
+	}
+}
+
+func Test_marshalRuleParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		paramsJSON string
+		want       string
+	}{
+		{
+			name:       "single filter groups are flattened",
+			paramsJSON: `{"facetFilters":[["brand_name:-Laser"]],"tagFilters":[["sale"]]}`,
+			want:       `{"facetFilters":["brand_name:-Laser"],"tagFilters":["sale"]}`,
+		},
+		{
+			name:       "filter groups combined with OR are kept",
+			paramsJSON: `{"optionalFilters":[["brand:apple"],["color:red","color:blue"]]}`,
+			want:       `{"optionalFilters":["brand:apple",["color:red","color:blue"]]}`,
+		},
+		{
+			name:       "string filter",
+			paramsJSON: `{"numericFilters":"price<100"}`,
+			want:       `{"numericFilters":["price<100"]}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := unmarshalConsequenceParamsJSON(tt.paramsJSON)
+			if err != nil {
+				t.Fatalf("unmarshalConsequenceParamsJSON() error = %v", err)
+			}
+			got, err := marshalRuleParams(*params)
+			if err != nil {
+				t.Fatalf("marshalRuleParams() error = %v", err)
+			}
+			if ok, _ := jsonBytesEqual([]byte(got), []byte(tt.want)); !ok {
+				t.Errorf("marshalRuleParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceRule_readParamsOrParamsJSON(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/shoes":
+			_, _ = w.Write([]byte(`{"objectID":"shoes","consequence":{"params":{"query":"shoes"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		consequence    map[string]interface{}
+		wantParamsJSON string
+		wantParams     []interface{}
+	}{
+		{
+			name:           "params_json",
+			consequence:    map[string]interface{}{"params_json": `{"query":"shoes"}`},
+			wantParamsJSON: `{"query":"shoes"}`,
+			wantParams:     []interface{}{},
+		},
+		{
+			name:           "not configured",
+			consequence:    map[string]interface{}{},
+			wantParamsJSON: `{"query":"shoes"}`,
+			wantParams:     []interface{}{},
+		},
+		{
+			name:           "deprecated params",
+			consequence:    map[string]interface{}{"params": []interface{}{map[string]interface{}{"query": "shoes"}}},
+			wantParamsJSON: "",
+			wantParams: []interface{}{map[string]interface{}{
+				"query":                            "shoes",
+				"object_query":                     []interface{}{},
+				"automatic_facet_filters":          []interface{}{},
+				"automatic_optional_facet_filters": []interface{}{},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "shoes",
+				"consequence": []interface{}{tt.consequence},
+			})
+			d.SetId("shoes")
+
+			if diags := resourceRuleRead(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceRuleRead() error = %v", diags)
+			}
+			if got := d.Get("consequence.0.params_json").(string); got != tt.wantParamsJSON {
+				t.Errorf("params_json = %v, want %v", got, tt.wantParamsJSON)
+			}
+			if got := d.Get("consequence.0.params").([]interface{}); !reflect.DeepEqual(got, tt.wantParams) {
+				t.Errorf("params = %v, want %v", got, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestResourceRule_refreshDeprecatedParamsWithoutDiff(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/shoes":
+			_, _ = w.Write([]byte(`{"objectID":"shoes","consequence":{"params":{"query":{"edits":[{"type":"remove","delete":"cheap"}]}}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	// The configuration written before `params_json` was introduced, whose state is kept as is without any migration.
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "shoes",
+		"consequence": []interface{}{map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{
+				"object_query": []interface{}{map[string]interface{}{"type": "remove", "delete": "cheap"}},
+			}},
+		}},
+	}
+	state := schema.TestResourceDataRaw(t, resourceRule().Schema, raw)
+	state.SetId("shoes")
+
+	d := resourceRule().Data(state.State())
+	if err := refreshRuleState(context.Background(), d, apiClient); err != nil {
+		t.Fatalf("refreshRuleState() error = %v", err)
+	}
+	diff, err := resourceRule().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), apiClient)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Diff() = %v, want empty", diff)
+	}
+}
+
+func TestResourceRule_automaticFacetFiltersRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var savedRule []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/brand":
+			savedRule, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"brand"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/brand":
+			_, _ = w.Write(savedRule)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	automaticFacetFilters := []interface{}{
+		map[string]interface{}{"facet": "brand", "score": 2, "disjunctive": true},
+	}
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "brand",
+		"conditions": []interface{}{map[string]interface{}{"pattern": "{facet:brand}", "anchoring": "contains"}},
+		"consequence": []interface{}{map[string]interface{}{
+			"params_json":             `{"query":"shoes"}`,
+			"automatic_facet_filters": automaticFacetFilters,
+		}},
+	})
+
+	if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleCreate() error = %v", diags)
+	}
+	if got := d.Get("consequence.0.automatic_facet_filters").([]interface{}); !reflect.DeepEqual(got, automaticFacetFilters) {
+		t.Errorf("automatic_facet_filters = %v, want %v", got, automaticFacetFilters)
+	}
+	// automaticFacetFilters is read into the block, so it must not leak into params_json.
+	if got := d.Get("consequence.0.params_json").(string); got != `{"query":"shoes"}` {
+		t.Errorf("params_json = %v, want %v", got, `{"query":"shoes"}`)
+	}
+}
+
+func Test_mapToRule_consequenceParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		consequence map[string]interface{}
+		wantJSON    string
+		wantErr     bool
+	}{
+		{
+			name: "automatic optional facet filters",
+			consequence: map[string]interface{}{
+				"automatic_optional_facet_filters": []interface{}{
+					map[string]interface{}{"facet": "brand", "score": 2, "disjunctive": true},
+					map[string]interface{}{"facet": "category"},
+				},
+			},
+			wantJSON: `{"automaticOptionalFacetFilters":[{"facet":"brand","disjunctive":true,"score":2},{"facet":"category","disjunctive":false,"score":1}]}`,
+		},
+		{
+			name: "automatic optional facet filters with params json",
+			consequence: map[string]interface{}{
+				"params_json": `{"query":"shoes","automaticFacetFilters":[{"facet":"color","disjunctive":false,"score":1}]}`,
+				"automatic_optional_facet_filters": []interface{}{
+					map[string]interface{}{"facet": "brand"},
+				},
+			},
+			wantJSON: `{"query":"shoes","automaticFacetFilters":[{"facet":"color","disjunctive":false,"score":1}],"automaticOptionalFacetFilters":[{"facet":"brand","disjunctive":false,"score":1}]}`,
+		},
+		{
+			name: "automatic optional facet filters in both params json and block",
+			consequence: map[string]interface{}{
+				"params_json": `{"automaticOptionalFacetFilters":[{"facet":"color","disjunctive":false,"score":1}]}`,
+				"automatic_optional_facet_filters": []interface{}{
+					map[string]interface{}{"facet": "brand"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "automatic facet filters with params json",
+			consequence: map[string]interface{}{
+				"params_json": `{"query":"shoes"}`,
+				"automatic_facet_filters": []interface{}{
+					map[string]interface{}{"facet": "brand", "score": 2, "disjunctive": true},
+				},
+			},
+			wantJSON: `{"query":"shoes","automaticFacetFilters":[{"facet":"brand","disjunctive":true,"score":2}]}`,
+		},
+		{
+			name: "automatic facet filters in both params json and block",
+			consequence: map[string]interface{}{
+				"params_json": `{"automaticFacetFilters":[{"facet":"color","disjunctive":false,"score":1}]}`,
+				"automatic_facet_filters": []interface{}{
+					map[string]interface{}{"facet": "brand"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "query remove",
+			consequence: map[string]interface{}{
+				"query_remove": []interface{}{"cheap", "free"},
+			},
+			wantJSON: `{"query":{"edits":[{"type":"remove","delete":"cheap"},{"type":"remove","delete":"free"}]}}`,
+		},
+		{
+			name: "query remove with params json",
+			consequence: map[string]interface{}{
+				"params_json":  `{"filters":"brand:apple"}`,
+				"query_remove": []interface{}{"cheap"},
+			},
+			wantJSON: `{"filters":"brand:apple","query":{"edits":[{"type":"remove","delete":"cheap"}]}}`,
+		},
+		{
+			name: "query in both params json and query remove",
+			consequence: map[string]interface{}{
+				"params_json":  `{"query":"shoes"}`,
+				"query_remove": []interface{}{"cheap"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "test",
+				"consequence": []interface{}{tt.consequence},
+			})
+			rule, err := mapToRule(d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mapToRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := json.Marshal(rule.Consequence.Params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, _ := jsonBytesEqual(got, []byte(tt.wantJSON)); !ok {
+				t.Errorf("mapToRule() params = %s, want %s", got, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func Test_mapToRule_emptyConsequence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		consequence map[string]interface{}
+		wantErr     bool
+	}{
+		{
+			name:        "empty params json",
+			consequence: map[string]interface{}{"params_json": `{}`},
+			wantErr:     true,
+		},
+		{
+			name:        "empty hide",
+			consequence: map[string]interface{}{"hide": []interface{}{}},
+			wantErr:     true,
+		},
+		{
+			name:        "user data only",
+			consequence: map[string]interface{}{"user_data": `{"banner":"sale"}`},
+			wantErr:     false,
+		},
+		{
+			name:        "hide only",
+			consequence: map[string]interface{}{"hide": []interface{}{"123"}},
+			wantErr:     false,
+		},
+		{
+			name:        "query_remove only",
+			consequence: map[string]interface{}{"query_remove": []interface{}{"cheap"}},
+			wantErr:     false,
+		},
+		{
+			name:        "filter_promotes only",
+			consequence: map[string]interface{}{"filter_promotes": true},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+				"index_name":  "test",
+				"object_id":   "test",
+				"consequence": []interface{}{tt.consequence},
+			})
+			_, err := mapToRule(d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mapToRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "consequence of rule (test) is empty") {
+				t.Errorf("mapToRule() error = %v, want empty consequence error", err)
+			}
+		})
+	}
+}
+
+func TestResourceRule_createWithEmptyConsequence(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "empty",
+		"consequence": []interface{}{map[string]interface{}{"params_json": `{}`}},
+	})
+
+	diags := resourceRuleCreate(context.Background(), d, apiClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "consequence of rule (empty) is empty") {
+		t.Errorf("resourceRuleCreate() error = %v, want empty consequence error", diags)
+	}
+}
+
+func TestResourceRule_userDataRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var savedRule []byte
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/rules/banner":
+			savedRule, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z","objectID":"banner"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/rules/banner":
+			_, _ = w.Write(savedRule)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceRule().Schema, map[string]interface{}{
+		"index_name":  "test",
+		"object_id":   "banner",
+		"consequence": []interface{}{map[string]interface{}{"user_data": `{"banner":"sale"}`}},
+	})
+
+	if diags := resourceRuleCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRuleCreate() error = %v", diags)
+	}
+	var rule struct {
+		Consequence struct {
+			UserData map[string]interface{} `json:"userData"`
+		} `json:"consequence"`
+	}
+	if err := json.Unmarshal(savedRule, &rule); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"banner": "sale"}; !reflect.DeepEqual(rule.Consequence.UserData, want) {
+		t.Errorf("userData of saved rule = %v, want %v", rule.Consequence.UserData, want)
+	}
+	if got := d.Get("consequence.0.user_data").(string); got != `{"banner":"sale"}` {
+		t.Errorf("user_data = %v, want %v", got, `{"banner":"sale"}`)
+	}
+}
+
+func Test_flattenQueryRemove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		query  *search.RuleQuery
+		want   []string
+		wantOk bool
+	}{
+		{
+			name:   "remove edits",
+			query:  search.NewRuleQueryObject(search.RuleQueryObjectQuery{Edits: []search.QueryEdit{search.RemoveEdit("cheap"), search.RemoveEdit("free")}}),
+			want:   []string{"cheap", "free"},
+			wantOk: true,
+		},
+		{
+			name:  "replace edit",
+			query: search.NewRuleQueryObject(search.RuleQueryObjectQuery{Edits: []search.QueryEdit{search.RemoveEdit("cheap"), search.ReplaceEdit("tv", "television")}}),
+		},
+		{
+			name:  "simple query",
+			query: search.NewRuleQuerySimple("shoes"),
+		},
+		{
+			name: "nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := flattenQueryRemove(tt.query)
+			if ok != tt.wantOk {
+				t.Fatalf("flattenQueryRemove() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenQueryRemove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenRuleConditions_alternatives(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition string
+		want      bool
+	}{
+		{
+			name:      "enabled",
+			condition: `{"pattern":"shoes","anchoring":"contains","alternatives":true}`,
+			want:      true,
+		},
+		{
+			name:      "disabled",
+			condition: `{"pattern":"shoes","anchoring":"contains","alternatives":false}`,
+			want:      false,
+		},
+		{
+			name:      "not returned",
+			condition: `{"pattern":"shoes","anchoring":"contains"}`,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule search.Rule
+			if err := json.Unmarshal([]byte(`{"objectID":"test","conditions":[`+tt.condition+`]}`), &rule); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			conditions := flattenRuleConditions(rule.Conditions)
+			if got := conditions[0].(map[string]interface{})["alternatives"]; got != tt.want {
+				t.Errorf("alternatives = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findUnknownRuleParams(t *testing.T) {
+	t.Parallel()
+
+	params := map[string]interface{}{
+		"facetFilter":           []interface{}{"brand:apple"},
+		"filters":               "price > 100",
+		"query":                 "phone",
+		"automaticFacetFilters": []interface{}{"brand"},
+		"hitsPerPage":           10,
+	}
+	if got, want := findUnknownRuleParams(params), []string{"facetFilter"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findUnknownRuleParams() = %v, want %v", got, want)
+	}
+}
+
+func TestResourceRule_validateKnownConsequenceParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		rejectUnknownParams bool
+		paramsJSON          string
+		wantErr             bool
+	}{
+		{
+			name:                "typo'd param with reject_unknown_params",
+			rejectUnknownParams: true,
+			paramsJSON:          `{"facetFilter":["brand:apple"]}`,
+			wantErr:             true,
+		},
+		{
+			name:                "known param with reject_unknown_params",
+			rejectUnknownParams: true,
+			paramsJSON:          `{"facetFilters":["brand:apple"]}`,
+			wantErr:             false,
+		},
+		{
+			name:                "typo'd param without reject_unknown_params",
+			rejectUnknownParams: false,
+			paramsJSON:          `{"facetFilter":["brand:apple"]}`,
+			wantErr:             false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				"index_name":            "test",
+				"object_id":             "test",
+				"reject_unknown_params": tt.rejectUnknownParams,
+				"consequence": []interface{}{map[string]interface{}{
+					"params_json": tt.paramsJSON,
+				}},
+			}
+			_, err := testResourceDiff(resourceRule(), raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "contains unknown search parameters (facetFilter)") {
+				t.Errorf("Diff() error = %v, want unknown search parameters error", err)
+			}
+		})
+	}
+}
+
 func testAccResourceRule(indexName, objectID string) string {
 	return `
 resource "algolia_index" "` + indexName + `" {
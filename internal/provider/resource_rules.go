@@ -0,0 +1,573 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourceRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRulesCreate,
+		ReadContext:   resourceRulesRead,
+		UpdateContext: resourceRulesUpdate,
+		DeleteContext: resourceRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRulesStateContext,
+		},
+		CustomizeDiff: customdiff.All(
+			validateRulesObjectIDsNotDuplicated,
+			validateRulesConditionsNotEmpty,
+		),
+		Description: `A configuration for multiple Rules of an index. To get more information about rules, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/rules/rules-overview/).
+
+Unlike ` + "`algolia_rule`" + `, all the rules are saved by a single batch request, so it's much faster to manage a large number of rules.
+Only the rules listed in the resource are managed unless ` + "`clear_existing_rules`" + ` is true.
+※ Don't manage the same rule with both ` + "`algolia_rules`" + ` and ` + "`algolia_rule`" + `.
+`,
+		// https://www.algolia.com/doc/api-reference/api-methods/batch-rules/
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index to apply rules.",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Rules of the index.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique identifier for the Rule (format: `[A-Za-z0-9_-]+`).",
+						},
+						"conditions": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    25,
+							Description: "A list of conditions that should apply to activate a Rule. You can use up to 25 conditions per Rule. See `conditions` of `algolia_rule` for the details.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"pattern": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Query pattern syntax.",
+									},
+									"anchoring": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"is", "startsWith", "endsWith", "contains"}, false),
+										Description:  "Whether the pattern parameter must match the beginning or the end of the query string, or both, or none. Possible values are `is`, `startsWith`, `endsWith` and `contains`.",
+									},
+									"alternatives": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Whether the `pattern` matches on plurals, synonyms, and typos.",
+									},
+									"context": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Rule context (format: `[A-Za-z0-9_-]+`). When specified, the Rule is only applied when the same context is specified at query time.",
+									},
+									"filters": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Filters to match against the filters of the query. At least one of `pattern`, `context` and `filters` must be set.",
+									},
+								},
+							},
+						},
+						"consequence": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "Consequence of the Rule. At least one of `params_json`, `promote`, `hide` and `user_data` must be set.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"params_json": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "Additional search parameters in JSON format. Any valid search parameter is allowed.",
+										DiffSuppressFunc: diffJsonSuppress,
+										ValidateFunc:     validation.StringIsJSON,
+									},
+									"promote": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Objects to promote as hits.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"object_ids": {
+													Type:     schema.TypeSet,
+													Elem:     &schema.Schema{Type: schema.TypeString},
+													Set:      schema.HashString,
+													Required: true,
+												},
+												"position": {
+													Type:        schema.TypeInt,
+													Required:    true,
+													Description: "The position to promote the object(s) to (zero-based).",
+												},
+											},
+										},
+									},
+									"filter_promotes": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Whether the promoted objects must match the filters of the query to be promoted.",
+									},
+									"hide": {
+										Type:        schema.TypeSet,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Set:         schema.HashString,
+										Optional:    true,
+										Description: "List of object IDs to hide from hits.",
+									},
+									"user_data": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "Custom JSON formatted string that will be appended to the userData array in the response.",
+										DiffSuppressFunc: diffJsonSuppress,
+										ValidateFunc:     validation.StringIsJSON,
+									},
+								},
+							},
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Description of the Rule. It is not interpreted by the API.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the Rule is enabled.",
+						},
+						"validity": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Time ranges when the Rule is active.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsRFC3339Time,
+										Description:  "Lower bound of the time range. RFC3339 format.",
+									},
+									"until": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsRFC3339Time,
+										Description:  "Upper bound of the time range. RFC3339 format.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"clear_existing_rules": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `Whether to delete the rules of the index which aren't listed in the resource when saving the rules. All the rules of the index are deleted on destroy when it's true.
+So don't set it to true if other rules of the index are managed separately.`,
+			},
+			"forward_to_replicas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to forward the rules to the replicas of the index, on save and delete. It isn't stored in Algolia, so it's set to `false` on import.",
+			},
+		},
+	}
+}
+
+func resourceRulesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveRules(ctx, d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("index_name").(string))
+
+	return resourceRulesRead(ctx, d, m)
+}
+
+func resourceRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshRulesState(ctx, d, m, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceRulesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	if err := saveRules(ctx, d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The rules removed from the resource are already deleted when clear_existing_rules is true.
+	if !d.Get("clear_existing_rules").(bool) {
+		o, n := d.GetChange("rules")
+		removedObjectIDs := removedRuleObjectIDs(ruleObjectIDs(o), ruleObjectIDs(n))
+		if err := deleteRules(ctx, apiClient, d.Get("index_name").(string), removedObjectIDs, d.Get("forward_to_replicas").(bool)); err != nil {
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+	}
+
+	return resourceRulesRead(ctx, d, m)
+}
+
+func resourceRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
+	forwardToReplicas := d.Get("forward_to_replicas").(bool)
+	if d.Get("clear_existing_rules").(bool) {
+		res, err := index.ClearRules(opt.ForwardToReplicas(forwardToReplicas), ctx)
+		if err != nil {
+			// The index may have been deleted out of band, then there are no rules to clear.
+			if algoliautil.IsNotFoundError(err) {
+				return nil
+			}
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+		if err = res.Wait(); err != nil {
+			return diag.FromErr(algoliautil.HumanizeError(err))
+		}
+		return nil
+	}
+
+	if err := deleteRules(ctx, apiClient, index.GetName(), ruleObjectIDs(d.Get("rules")), forwardToReplicas); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	return nil
+}
+
+func resourceRulesStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("index_name", d.Id()); err != nil {
+		return nil, err
+	}
+	// clear_existing_rules and forward_to_replicas aren't stored in Algolia, so the defaults are set.
+	if err := d.Set("clear_existing_rules", false); err != nil {
+		return nil, err
+	}
+	if err := d.Set("forward_to_replicas", false); err != nil {
+		return nil, err
+	}
+	// All the rules of the index are imported since there are no rules owned by the resource yet.
+	if err := refreshRulesState(ctx, d, m, true); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("index (%s) is not found", d.Get("index_name").(string))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// refreshRulesState reads the rules owned by the resource, in the order of the configuration.
+// All the rules of the index are read when importAll is true.
+func refreshRulesState(ctx context.Context, d *schema.ResourceData, m interface{}, importAll bool) error {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("index_name").(string)
+	rulesByObjectID, err := browseRules(ctx, apiClient.searchClient.InitIndex(indexName))
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("index (%s) of rules not found, removing from state", indexName))
+			d.SetId("")
+			return nil
+		}
+		return algoliautil.HumanizeError(err)
+	}
+
+	rules, err := flattenRules(ctx, indexName, rulesByObjectID, d.Get("rules"), importAll)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{
+		"index_name": indexName,
+		"rules":      rules,
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func saveRules(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	apiClient := m.(*apiClient)
+
+	rules, err := mapToRules(d.Get("rules"))
+	if err != nil {
+		return err
+	}
+
+	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
+	opts := []interface{}{
+		opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool)),
+		opt.ClearExistingRules(d.Get("clear_existing_rules").(bool)),
+		ctx,
+	}
+	err = retryWrite(ctx, timeout, func() error {
+		res, err := index.SaveRules(rules, opts...)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	return nil
+}
+
+// deleteRules deletes the rules of the given object IDs. The rules which are already deleted are ignored.
+func deleteRules(ctx context.Context, apiClient *apiClient, indexName string, objectIDs []string, forwardToReplicas bool) error {
+	index := apiClient.searchClient.InitIndex(indexName)
+
+	var responses []search.UpdateTaskRes
+	for _, objectID := range objectIDs {
+		res, err := index.DeleteRule(objectID, opt.ForwardToReplicas(forwardToReplicas), ctx)
+		if err != nil {
+			// The rule or the index may have been deleted out of band.
+			if algoliautil.IsNotFoundError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to delete rule (%s) of index (%s): %w", objectID, indexName, err)
+		}
+		responses = append(responses, res)
+	}
+	for _, res := range responses {
+		if err := res.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// browseRules returns all the rules of the index by the object ID.
+func browseRules(ctx context.Context, index searchIndex) (map[string]search.Rule, error) {
+	it, err := index.BrowseRules(opt.HitsPerPage(1000), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string]search.Rule{}
+	for {
+		rule, err := it.Next()
+		if err == io.EOF {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules[rule.ObjectID] = *rule
+	}
+}
+
+// flattenRules converts the rules to `rules` in the order of the configured ones. The configured rules which
+// don't exist anymore are dropped. The other rules of the index follow in the order of the object ID when includeUnowned is true.
+func flattenRules(ctx context.Context, indexName string, rulesByObjectID map[string]search.Rule, configured interface{}, includeUnowned bool) ([]interface{}, error) {
+	configuredRules := map[string]map[string]interface{}{}
+	var objectIDs []string
+	configuredList, _ := configured.([]interface{})
+	for _, v := range configuredList {
+		ruleData, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		objectID := ruleData["object_id"].(string)
+		configuredRules[objectID] = ruleData
+		objectIDs = append(objectIDs, objectID)
+	}
+	if includeUnowned {
+		var unownedObjectIDs []string
+		for objectID := range rulesByObjectID {
+			if _, ok := configuredRules[objectID]; !ok {
+				unownedObjectIDs = append(unownedObjectIDs, objectID)
+			}
+		}
+		sort.Strings(unownedObjectIDs)
+		objectIDs = append(objectIDs, unownedObjectIDs...)
+	}
+
+	var rules []interface{}
+	for _, objectID := range objectIDs {
+		rule, ok := rulesByObjectID[objectID]
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("rule (%s) not found in index (%s), removing from state", objectID, indexName))
+			continue
+		}
+		ruleData, err := flattenRulesRule(rule, configuredRules[objectID])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, ruleData)
+	}
+	return rules, nil
+}
+
+func mapToRules(configured interface{}) ([]search.Rule, error) {
+	var rules []search.Rule
+	for _, v := range configured.([]interface{}) {
+		rule, err := mapToRulesRule(v.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// mapToRulesRule converts the data of a rule in `rules` to the rule.
+func mapToRulesRule(ruleData map[string]interface{}) (search.Rule, error) {
+	rule := search.Rule{
+		ObjectID:    ruleData["object_id"].(string),
+		Description: ruleData["description"].(string),
+		Enabled:     opt.Enabled(ruleData["enabled"].(bool)),
+		Validity:    unmarshalValidity(ruleData["validity"]),
+	}
+	unmarshalConditions(ruleData["conditions"], &rule)
+
+	var err error
+	rule.Consequence, err = unmarshalConsequence(ruleData["consequence"])
+	if err != nil {
+		return rule, fmt.Errorf("invalid consequence of rule (%s): %w", rule.ObjectID, err)
+	}
+	if err := validateRuleConsequenceNotEmpty(rule); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+// flattenRulesRule converts the rule to the data of a rule in `rules`.
+// The configured params_json and validity are kept as they are if they're equivalent to the rule.
+func flattenRulesRule(rule search.Rule, configured map[string]interface{}) (map[string]interface{}, error) {
+	var configuredParamsJSON string
+	var configuredValidity interface{} = []interface{}{}
+	if configured != nil {
+		if l, ok := configured["consequence"].([]interface{}); ok && len(l) > 0 && l[0] != nil {
+			configuredParamsJSON, _ = l[0].(map[string]interface{})["params_json"].(string)
+		}
+		configuredValidity = configured["validity"]
+	}
+
+	consequence := map[string]interface{}{}
+	if rule.Consequence.Params != nil {
+		paramsJSON, err := marshalRuleParams(*rule.Consequence.Params)
+		if err != nil {
+			return nil, err
+		}
+		if isRuleParamsJSONEquivalent(configuredParamsJSON, paramsJSON) {
+			paramsJSON = configuredParamsJSON
+		}
+		if paramsJSON != "{}" {
+			consequence["params_json"] = paramsJSON
+		}
+	}
+	if err := flattenRuleConsequenceObjects(rule.Consequence, consequence); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"object_id":   rule.ObjectID,
+		"conditions":  flattenRuleConditions(rule.Conditions),
+		"consequence": []interface{}{consequence},
+		"description": rule.Description,
+		"enabled":     rule.Enabled.Get(),
+		"validity":    flattenValidity(rule.Validity, configuredValidity),
+	}, nil
+}
+
+// ruleObjectIDs returns the object IDs of the rules in `rules`.
+func ruleObjectIDs(rules interface{}) []string {
+	var objectIDs []string
+	for _, v := range rules.([]interface{}) {
+		if ruleData, ok := v.(map[string]interface{}); ok {
+			objectIDs = append(objectIDs, ruleData["object_id"].(string))
+		}
+	}
+	return objectIDs
+}
+
+// removedRuleObjectIDs returns the object IDs in oldObjectIDs which aren't in newObjectIDs.
+func removedRuleObjectIDs(oldObjectIDs, newObjectIDs []string) []string {
+	kept := map[string]bool{}
+	for _, objectID := range newObjectIDs {
+		kept[objectID] = true
+	}
+	var removed []string
+	for _, objectID := range oldObjectIDs {
+		if !kept[objectID] {
+			removed = append(removed, objectID)
+		}
+	}
+	return removed
+}
+
+// validateRulesObjectIDsNotDuplicated returns an error if the same `object_id` is used by multiple rules,
+// since they would overwrite one another in Algolia.
+func validateRulesObjectIDsNotDuplicated(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("rules") {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, objectID := range ruleObjectIDs(d.Get("rules")) {
+		if objectID == "" {
+			continue
+		}
+		if seen[objectID] {
+			return fmt.Errorf("rule object_id '%s' is duplicated, object_id must be unique", objectID)
+		}
+		seen[objectID] = true
+	}
+	return nil
+}
+
+// validateRulesConditionsNotEmpty returns an error if a condition has none of `pattern`, `context` and `filters`, which Algolia rejects.
+func validateRulesConditionsNotEmpty(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("rules") {
+		return nil
+	}
+
+	for _, v := range d.Get("rules").([]interface{}) {
+		ruleData, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _ := ruleData["conditions"].([]interface{})
+		for i, c := range conditions {
+			condition, _ := c.(map[string]interface{})
+			if condition == nil || (condition["pattern"] == "" && condition["context"] == "" && condition["filters"] == "") {
+				return fmt.Errorf("`conditions.%d` of rule '%s' must have at least one of `pattern`, `context` and `filters`", i, ruleData["object_id"])
+			}
+		}
+	}
+	return nil
+}
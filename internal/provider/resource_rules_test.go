@@ -0,0 +1,391 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceRules(t *testing.T) {
+	indexName := randResourceID(100)
+	resourceName := fmt.Sprintf("algolia_rules.%s", indexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRules(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index_name", indexName),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.object_id", "apple"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.conditions.0.pattern", "{facet:brand}"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.object_id", "samsung"),
+				),
+			},
+			{
+				Config: testAccResourceRulesUpdate(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.object_id", "apple"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     indexName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckRulesDestroy,
+	})
+}
+
+func TestResourceRules_validateRulesObjectIDsNotDuplicated(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{"object_id": "apple", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone"}`}}},
+			map[string]interface{}{"object_id": "apple", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"ipad"}`}}},
+		},
+	}
+	_, err := testResourceDiff(resourceRules(), raw)
+	if err == nil || !regexp.MustCompile("rule object_id 'apple' is duplicated").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want duplicated object_id error", err)
+	}
+}
+
+func TestResourceRules_validateRulesConditionsNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{
+				"object_id":   "apple",
+				"conditions":  []interface{}{map[string]interface{}{"anchoring": "is"}},
+				"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone"}`}},
+			},
+		},
+	}
+	_, err := testResourceDiff(resourceRules(), raw)
+	if err == nil || !regexp.MustCompile("`conditions.0` of rule 'apple' must have at least one of").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want empty condition error", err)
+	}
+}
+
+// testRulesHandler is a fake of the rules API which keeps the rules of the index `test` in memory.
+type testRulesHandler struct {
+	t *testing.T
+
+	mu                 sync.Mutex
+	rules              map[string]json.RawMessage
+	batchRequests      int
+	clearExistingRules []string
+	deletedObjectIDs   []string
+}
+
+func (h *testRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/batch":
+		h.batchRequests++
+		h.clearExistingRules = append(h.clearExistingRules, r.URL.Query().Get("clearExistingRules"))
+		var rules []json.RawMessage
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &rules); err != nil {
+			h.t.Errorf("failed to unmarshal rules: %v", err)
+		}
+		if r.URL.Query().Get("clearExistingRules") == "true" {
+			h.rules = map[string]json.RawMessage{}
+		}
+		for _, rule := range rules {
+			var v struct {
+				ObjectID string `json:"objectID"`
+			}
+			_ = json.Unmarshal(rule, &v)
+			h.rules[v.ObjectID] = rule
+		}
+		_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/1/indexes/test/rules/"):
+		objectID := strings.TrimPrefix(r.URL.Path, "/1/indexes/test/rules/")
+		h.deletedObjectIDs = append(h.deletedObjectIDs, objectID)
+		delete(h.rules, objectID)
+		_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+		_, _ = w.Write([]byte(`{"status":"published"}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/rules/search":
+		var objectIDs []string
+		for objectID := range h.rules {
+			objectIDs = append(objectIDs, objectID)
+		}
+		sort.Strings(objectIDs)
+		hits := []json.RawMessage{}
+		for _, objectID := range objectIDs {
+			hits = append(hits, h.rules[objectID])
+		}
+		b, _ := json.Marshal(map[string]interface{}{"hits": hits, "nbHits": len(hits), "page": 0, "nbPages": 1})
+		_, _ = w.Write(b)
+	default:
+		h.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestResourceRules_createInBatch(t *testing.T) {
+	t.Parallel()
+
+	handler := &testRulesHandler{t: t, rules: map[string]json.RawMessage{}}
+	apiClient := newTestAPIClientWithHandler(handler.ServeHTTP)
+
+	d := schema.TestResourceDataRaw(t, resourceRules().Schema, map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{
+				"object_id":   "samsung",
+				"conditions":  []interface{}{map[string]interface{}{"pattern": "galaxy", "anchoring": "contains"}},
+				"consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"galaxy phone"}`}},
+			},
+			map[string]interface{}{
+				"object_id":   "apple",
+				"consequence": []interface{}{map[string]interface{}{"hide": []interface{}{"android"}, "user_data": `{"banner":"apple"}`}},
+			},
+		},
+	})
+
+	if diags := resourceRulesCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRulesCreate() error = %v", diags)
+	}
+	if handler.batchRequests != 1 {
+		t.Errorf("batch requests = %d, want 1", handler.batchRequests)
+	}
+	if want := []string{"false"}; !reflect.DeepEqual(handler.clearExistingRules, want) {
+		t.Errorf("clearExistingRules of requests = %v, want %v", handler.clearExistingRules, want)
+	}
+	if got := d.Id(); got != "test" {
+		t.Errorf("Id() = %v, want test", got)
+	}
+	// The rules are kept in the configured order even though they're browsed in a different order.
+	if got, want := ruleObjectIDs(d.Get("rules")), []string{"samsung", "apple"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object IDs of rules = %v, want %v", got, want)
+	}
+	if got := d.Get("rules.0.consequence.0.params_json").(string); got != `{"query":"galaxy phone"}` {
+		t.Errorf("rules.0.consequence.0.params_json = %v, want {\"query\":\"galaxy phone\"}", got)
+	}
+	if got := d.Get("rules.1.consequence.0.user_data").(string); got != `{"banner":"apple"}` {
+		t.Errorf("rules.1.consequence.0.user_data = %v, want {\"banner\":\"apple\"}", got)
+	}
+}
+
+func TestResourceRules_readOnlyOwnedRules(t *testing.T) {
+	t.Parallel()
+
+	handler := &testRulesHandler{t: t, rules: map[string]json.RawMessage{
+		"apple":  json.RawMessage(`{"objectID":"apple","consequence":{"params":{"query":"iphone"}},"enabled":true}`),
+		"google": json.RawMessage(`{"objectID":"google","consequence":{"params":{"query":"pixel"}},"enabled":true}`),
+	}}
+	apiClient := newTestAPIClientWithHandler(handler.ServeHTTP)
+
+	d := schema.TestResourceDataRaw(t, resourceRules().Schema, map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{"object_id": "apple", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone"}`}}},
+			map[string]interface{}{"object_id": "samsung", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"galaxy"}`}}},
+		},
+	})
+	d.SetId("test")
+
+	if diags := resourceRulesRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRulesRead() error = %v", diags)
+	}
+	// The rule which isn't owned by the resource is ignored, and the deleted one is removed.
+	if got, want := ruleObjectIDs(d.Get("rules")), []string{"apple"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object IDs of rules = %v, want %v", got, want)
+	}
+}
+
+func TestResourceRules_updateDeletesRemovedRules(t *testing.T) {
+	t.Parallel()
+
+	handler := &testRulesHandler{t: t, rules: map[string]json.RawMessage{
+		"google": json.RawMessage(`{"objectID":"google","consequence":{"params":{"query":"pixel"}},"enabled":true}`),
+	}}
+	apiClient := newTestAPIClientWithHandler(handler.ServeHTTP)
+
+	r := resourceRules()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{"object_id": "apple", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone"}`}}},
+			map[string]interface{}{"object_id": "samsung", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"galaxy"}`}}},
+		},
+	})
+	if diags := resourceRulesCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRulesCreate() error = %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"index_name": "test",
+		"rules": []interface{}{
+			map[string]interface{}{"object_id": "apple", "consequence": []interface{}{map[string]interface{}{"params_json": `{"query":"iphone 15"}`}}},
+		},
+	}), apiClient)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("Data() error = %v", err)
+	}
+	if diags := resourceRulesUpdate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRulesUpdate() error = %v", diags)
+	}
+
+	if want := []string{"samsung"}; !reflect.DeepEqual(handler.deletedObjectIDs, want) {
+		t.Errorf("deleted object IDs = %v, want %v", handler.deletedObjectIDs, want)
+	}
+	// The rule which isn't owned by the resource must be kept.
+	if _, ok := handler.rules["google"]; !ok {
+		t.Errorf("rule google is deleted, want it to be kept")
+	}
+	if got := d.Get("rules.0.consequence.0.params_json").(string); got != `{"query":"iphone 15"}` {
+		t.Errorf("rules.0.consequence.0.params_json = %v, want {\"query\":\"iphone 15\"}", got)
+	}
+
+	if diags := resourceRulesDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceRulesDelete() error = %v", diags)
+	}
+	if want := []string{"samsung", "apple"}; !reflect.DeepEqual(handler.deletedObjectIDs, want) {
+		t.Errorf("deleted object IDs = %v, want %v", handler.deletedObjectIDs, want)
+	}
+}
+
+func TestResourceRules_importAllRules(t *testing.T) {
+	t.Parallel()
+
+	handler := &testRulesHandler{t: t, rules: map[string]json.RawMessage{
+		"samsung": json.RawMessage(`{"objectID":"samsung","consequence":{"params":{"query":"galaxy"}},"enabled":false}`),
+		"apple":   json.RawMessage(`{"objectID":"apple","consequence":{"params":{"query":"iphone"}},"enabled":true}`),
+	}}
+	apiClient := newTestAPIClientWithHandler(handler.ServeHTTP)
+
+	d := schema.TestResourceDataRaw(t, resourceRules().Schema, map[string]interface{}{})
+	d.SetId("test")
+
+	ds, err := resourceRulesStateContext(context.Background(), d, apiClient)
+	if err != nil {
+		t.Fatalf("resourceRulesStateContext() error = %v", err)
+	}
+	if got, want := ruleObjectIDs(ds[0].Get("rules")), []string{"apple", "samsung"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("object IDs of rules = %v, want %v", got, want)
+	}
+	if got := ds[0].Get("rules.1.enabled").(bool); got {
+		t.Errorf("rules.1.enabled = %v, want false", got)
+	}
+}
+
+func testAccResourceRules(indexName string) string {
+	return `
+resource "algolia_index" "` + indexName + `" {
+  name = "` + indexName + `"
+  deletion_protection = false
+}
+
+resource "algolia_rules" "` + indexName + `" {
+  index_name = algolia_index.` + indexName + `.name
+
+  rules {
+    object_id = "apple"
+
+    conditions {
+      pattern   = "{facet:brand}"
+      anchoring = "contains"
+    }
+
+    consequence {
+      params_json = jsonencode({
+        automaticFacetFilters = [{ facet = "brand", disjunctive = true }]
+      })
+    }
+  }
+  rules {
+    object_id = "samsung"
+
+    conditions {
+      pattern   = "galaxy"
+      anchoring = "is"
+    }
+
+    consequence {
+      hide = ["iphone"]
+    }
+  }
+}
+`
+}
+
+func testAccResourceRulesUpdate(indexName string) string {
+	return `
+resource "algolia_index" "` + indexName + `" {
+  name = "` + indexName + `"
+  deletion_protection = false
+}
+
+resource "algolia_rules" "` + indexName + `" {
+  index_name = algolia_index.` + indexName + `.name
+
+  rules {
+    object_id = "apple"
+    enabled   = false
+
+    conditions {
+      pattern   = "{facet:brand}"
+      anchoring = "contains"
+    }
+
+    consequence {
+      params_json = jsonencode({
+        automaticFacetFilters = [{ facet = "brand", disjunctive = true }]
+      })
+    }
+  }
+}
+`
+}
+
+func testAccCheckRulesDestroy(s *terraform.State) error {
+	apiClient := newTestAPIClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "algolia_rules" {
+			continue
+		}
+
+		rulesIter, err := apiClient.searchClient.InitIndex(rs.Primary.ID).BrowseRules()
+		if err != nil {
+			return err
+		}
+		if _, err := rulesIter.Next(); err != io.EOF {
+			return fmt.Errorf("rules for index '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+func resourceSynonym() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSynonymCreate,
+		ReadContext:   resourceSynonymRead,
+		UpdateContext: resourceSynonymUpdate,
+		DeleteContext: resourceSynonymDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSynonymStateContext,
+		},
+		CustomizeDiff: validateOneWaySynonymHasInput,
+		Description: `A configuration for a single synonym. To get more information about synonyms, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/optimize-search-results/adding-synonyms/).
+
+Unlike ` + "`algolia_synonyms`" + `, it only manages the synonym of the given ` + "`object_id`" + `, so multiple ` + "`algolia_synonym`" + ` resources can manage the synonyms of the same index.
+※ Don't use it together with ` + "`algolia_synonyms`" + ` for the same index, since ` + "`algolia_synonyms`" + ` replaces all the synonyms of the index.
+`,
+		// https://www.algolia.com/doc/api-reference/api-methods/save-synonym/
+		Schema: map[string]*schema.Schema{
+			"index_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the index to apply the synonym.",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier for the synonym. It can contain any character, and be of unlimited length.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(synonymTypes, false),
+				Description:  "The type of the synonym. Possible values are `synonym`, `oneWaySynonym`, `altCorrection1`, `altCorrection2` and `placeholder`.",
+			},
+			"synonyms": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of synonyms (up to 20 for type `synonym` and 100 for type `oneWaySynonym`). Required if type=`synonym` or type=`oneWaySynonym`.",
+			},
+			"input": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Defines the synonym. A word or expression, used as the basis for the array of synonyms. Required if type=`oneWaySynonym`.",
+			},
+			"word": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Single word, used as the basis for the below array of corrections. Required if type=`altCorrection1` or type=`altCorrection2`",
+			},
+			"corrections": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of corrections of the `word`. Required if type=`altCorrection1` or type=`altCorrection2`",
+			},
+			"placeholder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Single word, used as the basis for the below array of replacements. Required if type=`placeholder`",
+			},
+			"replacements": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of replacements of the placeholder. Required if type=`placeholder`",
+			},
+			"forward_to_replicas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to forward the synonym to the replicas of the index.",
+			},
+		},
+	}
+}
+
+func resourceSynonymCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveSynonym(ctx, d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("object_id").(string))
+
+	return resourceSynonymRead(ctx, d, m)
+}
+
+func resourceSynonymRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := refreshSynonymState(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceSynonymUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveSynonym(ctx, d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSynonymRead(ctx, d, m)
+}
+
+func resourceSynonymDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*apiClient)
+
+	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
+	res, err := index.DeleteSynonym(d.Id(), opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool)), ctx)
+	if err != nil {
+		// The synonym or the index may have been deleted out of band.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+	if err = res.Wait(); err != nil {
+		return diag.FromErr(algoliautil.HumanizeError(err))
+	}
+
+	return nil
+}
+
+func resourceSynonymStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	indexName, objectID, ok := strings.Cut(d.Id(), "/")
+	if !ok || indexName == "" || objectID == "" {
+		return nil, errors.New("import id must be {{index_name}}/{{object_id}}")
+	}
+
+	d.SetId(objectID)
+	if err := d.Set("index_name", indexName); err != nil {
+		return nil, err
+	}
+	// forward_to_replicas isn't stored in Algolia, so the default is set.
+	if err := d.Set("forward_to_replicas", false); err != nil {
+		return nil, err
+	}
+
+	if err := refreshSynonymState(ctx, d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("synonym (%s) is not found in index (%s)", objectID, indexName)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func refreshSynonymState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*apiClient)
+
+	indexName := d.Get("index_name").(string)
+	index := apiClient.searchClient.InitIndex(indexName)
+
+	var synonym search.Synonym
+	err := retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
+		var err error
+		synonym, err = index.GetSynonym(d.Id(), ctx)
+
+		if d.IsNewResource() && algoliautil.IsRetryableError(err) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("synonym (%s) not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return algoliautil.HumanizeError(err)
+	}
+
+	// The typed synonym is converted to the raw one to share the flattening with `algolia_synonyms`.
+	b, err := json.Marshal(synonym)
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonym (%s): %w", d.Id(), err)
+	}
+	var hit map[string]interface{}
+	if err := json.Unmarshal(b, &hit); err != nil {
+		return fmt.Errorf("failed to unmarshal synonym (%s): %w", d.Id(), err)
+	}
+
+	values := flattenSynonym(hit)
+	values["index_name"] = indexName
+	// The fields which aren't returned for the type are cleared.
+	for _, key := range []string{"input", "word", "placeholder"} {
+		if _, ok := values[key]; !ok {
+			values[key] = ""
+		}
+	}
+	for _, key := range []string{"synonyms", "corrections", "replacements"} {
+		if _, ok := values[key]; !ok {
+			values[key] = []string{}
+		}
+	}
+	if err := setValues(d, values); err != nil {
+		return err
+	}
+
+	d.SetId(synonym.ObjectID())
+
+	return nil
+}
+
+func saveSynonym(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	apiClient := m.(*apiClient)
+
+	index := apiClient.searchClient.InitIndex(d.Get("index_name").(string))
+	synonym := mapToSynonym(map[string]interface{}{
+		"object_id":    d.Get("object_id"),
+		"type":         d.Get("type"),
+		"synonyms":     d.Get("synonyms"),
+		"input":        d.Get("input"),
+		"word":         d.Get("word"),
+		"corrections":  d.Get("corrections"),
+		"placeholder":  d.Get("placeholder"),
+		"replacements": d.Get("replacements"),
+	})
+	forwardToReplicas := opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool))
+	err := retryWrite(ctx, timeout, func() error {
+		res, err := index.SaveSynonym(synonym, forwardToReplicas, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	return nil
+}
+
+// validateOneWaySynonymHasInput returns an error if a `oneWaySynonym` has no `input`, which Algolia requires.
+func validateOneWaySynonymHasInput(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("input") {
+		return nil
+	}
+	if d.Get("type").(string) == string(search.OneWaySynonymType) && d.Get("input").(string) == "" {
+		return fmt.Errorf("`input` is required for synonym '%s' of type `oneWaySynonym`", d.Get("object_id").(string))
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSynonym(t *testing.T) {
+	indexName := randResourceID(100)
+	resourceName := fmt.Sprintf("algolia_synonym.%s_1", indexName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSynonym(indexName, "smartphone"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index_name", indexName),
+					resource.TestCheckResourceAttr(resourceName, "object_id", "test_1"),
+					resource.TestCheckResourceAttr(resourceName, "type", "oneWaySynonym"),
+					resource.TestCheckResourceAttr(resourceName, "input", "smartphone"),
+					testCheckResourceListAttr(resourceName, "synonyms", []string{"iPhone", "Pixel"}),
+					resource.TestCheckResourceAttr(fmt.Sprintf("algolia_synonym.%s_2", indexName), "word", "tablet"),
+				),
+			},
+			{
+				Config: testAccResourceSynonym(indexName, "mobile phone"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "input", "mobile phone"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     fmt.Sprintf("%s/test_1", indexName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckSynonymDestroy,
+	})
+}
+
+func TestResourceSynonym_createAndRead(t *testing.T) {
+	t.Parallel()
+
+	var saved map[string]interface{}
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/test/synonyms/test_1":
+			if got := r.URL.Query().Get("forwardToReplicas"); got != "true" {
+				t.Errorf("forwardToReplicas = %v, want true", got)
+			}
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &saved)
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/synonyms/test_1":
+			b, _ := json.Marshal(saved)
+			_, _ = w.Write(b)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSynonym().Schema, map[string]interface{}{
+		"index_name":          "test",
+		"object_id":           "test_1",
+		"type":                "placeholder",
+		"placeholder":         "<model>",
+		"replacements":        []interface{}{"6"},
+		"forward_to_replicas": true,
+	})
+
+	if diags := resourceSynonymCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceSynonymCreate() error = %v", diags)
+	}
+
+	if got, want := saved["type"], "placeholder"; got != want {
+		t.Errorf("saved type = %v, want %v", got, want)
+	}
+	if got := d.Id(); got != "test_1" {
+		t.Errorf("id = %v, want test_1", got)
+	}
+	if got := d.Get("placeholder").(string); got != "<model>" {
+		t.Errorf("placeholder = %v, want <model>", got)
+	}
+	if got, want := castStringSet(d.Get("replacements")), []string{"6"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("replacements = %v, want %v", got, want)
+	}
+	if got := d.Get("forward_to_replicas").(bool); !got {
+		t.Errorf("forward_to_replicas = %v, want true", got)
+	}
+}
+
+func TestResourceSynonym_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Synonym set does not exist","status":404}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSynonym().Schema, map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "test_1",
+		"type":       "synonym",
+	})
+	d.SetId("test_1")
+
+	if diags := resourceSynonymRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceSynonymRead() error = %v", diags)
+	}
+	if got := d.Id(); got != "" {
+		t.Errorf("id = %v, want removed from state", got)
+	}
+}
+
+func TestResourceSynonym_importInvalidID(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceSynonym().Schema, map[string]interface{}{})
+	d.SetId("test_1")
+
+	_, err := resourceSynonymStateContext(context.Background(), d, &apiClient{})
+	if err == nil || !regexp.MustCompile(`import id must be {{index_name}}/{{object_id}}`).MatchString(err.Error()) {
+		t.Errorf("resourceSynonymStateContext() error = %v, want invalid id error", err)
+	}
+}
+
+func TestResourceSynonym_validateOneWaySynonymHasInput(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"object_id":  "test_1",
+		"type":       "oneWaySynonym",
+		"synonyms":   []interface{}{"iPhone"},
+	}
+	_, err := testResourceDiff(resourceSynonym(), raw)
+	if err == nil || !regexp.MustCompile("`input` is required").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want missing input error", err)
+	}
+}
+
+func testAccResourceSynonym(indexName string, input string) string {
+	return `
+resource "algolia_index" "` + indexName + `" {
+  name = "` + indexName + `"
+  deletion_protection = false
+}
+
+resource "algolia_synonym" "` + indexName + `_1" {
+  index_name = algolia_index.` + indexName + `.name
+  object_id  = "test_1"
+  type       = "oneWaySynonym"
+  input      = "` + input + `"
+  synonyms   = ["iPhone", "Pixel"]
+}
+
+resource "algolia_synonym" "` + indexName + `_2" {
+  index_name  = algolia_index.` + indexName + `.name
+  object_id   = "test_2"
+  type        = "altCorrection1"
+  word        = "tablet"
+  corrections = ["ipad"]
+}
+`
+}
+
+func testAccCheckSynonymDestroy(s *terraform.State) error {
+	apiClient := newTestAPIClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "algolia_synonym" {
+			continue
+		}
+
+		_, err := apiClient.searchClient.InitIndex(rs.Primary.Attributes["index_name"]).GetSynonym(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("synonym '%s' still exists", rs.Primary.ID)
+		}
+		if _, ok := errs.IsAlgoliaErrWithCode(err, http.StatusNotFound); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,14 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"io"
+	"strings"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
 )
 
@@ -22,6 +24,10 @@ func resourceSynonyms() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSynonymsStateContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateSynonymObjectIDsNotDuplicated,
+			validateOneWaySynonymsHaveInput,
+		),
 		Description: `A configuration for synonyms. To get more information about synonyms, see the [Official Documentation](https://www.algolia.com/doc/guides/managing-results/optimize-search-results/adding-synonyms/).
 
 ※ **It replaces any existing synonyms set for the index.** So you can't have multiple ` + "`algolia_synonyms`" + ` resources for the same index.
@@ -48,8 +54,8 @@ func resourceSynonyms() *schema.Resource {
 						"type": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"synonym", "oneWaySynonym", "altCorrection1", "altCorrection2", "placeholder"}, false),
-							Description:  "The type of the synonym. Possible values are `synonym`, `oneWaySynonym`, `altCorrection1`, `altCorrection2` and `placeholder`.",
+							ValidateFunc: validateSynonymType,
+							Description:  "The type of the synonym. Possible values are `synonym`, `oneWaySynonym`, `altCorrection1`, `altCorrection2` and `placeholder`. Other types are passed through to Algolia as is with a warning.",
 						},
 						"synonyms": {
 							Type:        schema.TypeSet,
@@ -87,6 +93,13 @@ func resourceSynonyms() *schema.Resource {
 					},
 				},
 			},
+			"forward_to_replicas": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `Whether to forward the synonyms to the replicas of the index. When it's true, the synonyms of the replicas are replaced with the synonyms of this resource, and cleared on destroy.
+So don't set it to true if the synonyms of the replicas are managed separately (e.g. by another ` + "`algolia_synonyms`" + ` resource), otherwise they overwrite one another.`,
+			},
 		},
 	}
 }
@@ -95,12 +108,16 @@ func resourceSynonymsCreate(ctx context.Context, d *schema.ResourceData, m inter
 	apiClient := m.(*apiClient)
 
 	indexName := d.Get("index_name").(string)
-	res, err := apiClient.searchClient.InitIndex(indexName).ReplaceAllSynonyms(mapToSynonyms(d), ctx)
+	forwardToReplicas := opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool))
+	err := retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		res, err := apiClient.searchClient.InitIndex(indexName).ReplaceAllSynonyms(mapToSynonyms(d), forwardToReplicas, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(indexName)
@@ -119,12 +136,16 @@ func resourceSynonymsUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	apiClient := m.(*apiClient)
 
 	indexName := d.Get("index_name").(string)
-	res, err := apiClient.searchClient.InitIndex(indexName).ReplaceAllSynonyms(mapToSynonyms(d), ctx)
+	forwardToReplicas := opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool))
+	err := retryWrite(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
+		res, err := apiClient.searchClient.InitIndex(indexName).ReplaceAllSynonyms(mapToSynonyms(d), forwardToReplicas, ctx)
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(indexName)
@@ -135,12 +156,16 @@ func resourceSynonymsUpdate(ctx context.Context, d *schema.ResourceData, m inter
 func resourceSynonymsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*apiClient)
 
-	res, err := apiClient.searchClient.InitIndex(d.Id()).ClearSynonyms(ctx)
+	res, err := apiClient.searchClient.InitIndex(d.Id()).ClearSynonyms(opt.ForwardToReplicas(d.Get("forward_to_replicas").(bool)), ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		// The index may have been deleted out of band, then there are no synonyms to clear.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
@@ -150,6 +175,10 @@ func resourceSynonymsStateContext(ctx context.Context, d *schema.ResourceData, m
 	if err := d.Set("index_name", d.Id()); err != nil {
 		return nil, err
 	}
+	// forward_to_replicas isn't stored in Algolia, so the default is set.
+	if err := d.Set("forward_to_replicas", false); err != nil {
+		return nil, err
+	}
 	if err := refreshSynonymsState(ctx, d, m); err != nil {
 		return nil, err
 	}
@@ -160,61 +189,78 @@ func resourceSynonymsStateContext(ctx context.Context, d *schema.ResourceData, m
 func refreshSynonymsState(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	apiClient := m.(*apiClient)
 
-	indexName := d.Id()
-	iter, err := apiClient.searchClient.InitIndex(indexName).BrowseSynonyms(ctx)
+	synonyms, err := listSynonyms(ctx, apiClient, d.Id())
 	if err != nil {
 		if algoliautil.IsNotFoundError(err) {
 			tflog.Warn(ctx, fmt.Sprintf("synonyms for (%s) not found, removing from state", d.Id()))
 			d.SetId("")
 			return nil
 		}
+		return algoliautil.HumanizeError(err)
+	}
+
+	values := map[string]interface{}{
+		"synonyms": synonyms,
+	}
+	if err := setValues(d, values); err != nil {
 		return err
 	}
 
+	return nil
+}
+
+// listSynonyms returns all the synonyms of the index flattened to the resource data.
+// It's shared by the resource and the data source.
+func listSynonyms(ctx context.Context, apiClient *apiClient, indexName string) ([]interface{}, error) {
+	// Raw hits are used instead of BrowseSynonyms since the client fails to decode synonyms of unknown types.
+	res, err := apiClient.searchClient.InitIndex(indexName).SearchSynonyms("", opt.HitsPerPage(1000), ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var synonyms []interface{}
-	for {
-		synonym, err := iter.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		synonymData := map[string]interface{}{
-			"object_id": synonym.ObjectID(),
-			"type":      string(synonym.Type()),
+	for _, hit := range res.Hits {
+		synonyms = append(synonyms, flattenSynonym(hit))
+	}
+	return synonyms, nil
+}
+
+// validateSynonymObjectIDsNotDuplicated returns an error if the same `object_id` is used by multiple synonyms,
+// since they would overwrite one another in Algolia.
+func validateSynonymObjectIDsNotDuplicated(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("synonyms") {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, v := range d.Get("synonyms").(*schema.Set).List() {
+		objectID := v.(map[string]interface{})["object_id"].(string)
+		if objectID == "" {
+			continue
 		}
-		switch synonym.Type() {
-		case search.RegularSynonymType:
-			rs := synonym.(search.RegularSynonym)
-			synonymData["synonyms"] = rs.Synonyms
-		case search.OneWaySynonymType:
-			ows := synonym.(search.OneWaySynonym)
-			synonymData["input"] = ows.Input
-			synonymData["synonyms"] = ows.Synonyms
-		case search.AltCorrection1Type:
-			ac1 := synonym.(search.AltCorrection1)
-			synonymData["word"] = ac1.Word
-			synonymData["corrections"] = ac1.Corrections
-		case search.AltCorrection2Type:
-			ac2 := synonym.(search.AltCorrection2)
-			synonymData["word"] = ac2.Word
-			synonymData["corrections"] = ac2.Corrections
-		case search.PlaceholderType:
-			p := synonym.(search.Placeholder)
-			synonymData["placeholder"] = p.Placeholder
-			synonymData["replacements"] = p.Replacements
+		if seen[objectID] {
+			return fmt.Errorf("synonym object_id '%s' is duplicated, object_id must be unique", objectID)
 		}
-		synonyms = append(synonyms, synonymData)
+		seen[objectID] = true
 	}
+	return nil
+}
 
-	values := map[string]interface{}{
-		"synonyms": synonyms,
-	}
-	if err := setValues(d, values); err != nil {
-		return err
+// validateOneWaySynonymsHaveInput returns an error if a `oneWaySynonym` has no `input`, which Algolia requires.
+func validateOneWaySynonymsHaveInput(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("synonyms") {
+		return nil
 	}
 
+	for _, v := range d.Get("synonyms").(*schema.Set).List() {
+		synonymData := v.(map[string]interface{})
+		if synonymData["type"].(string) != string(search.OneWaySynonymType) {
+			continue
+		}
+		if synonymData["input"].(string) == "" {
+			return fmt.Errorf("`input` is required for synonym '%s' of type `oneWaySynonym`", synonymData["object_id"].(string))
+		}
+	}
 	return nil
 }
 
@@ -226,24 +272,129 @@ func mapToSynonyms(d *schema.ResourceData) []search.Synonym {
 
 	var synonyms []search.Synonym
 	for _, v := range l.List() {
-		synonymData := v.(map[string]interface{})
-		objectID := synonymData["object_id"].(string)
-
-		var synonym search.Synonym
-		switch search.SynonymType(synonymData["type"].(string)) {
-		case search.RegularSynonymType:
-			synonym = search.NewRegularSynonym(objectID, castStringSet(synonymData["synonyms"])...)
-		case search.OneWaySynonymType:
-			synonym = search.NewOneWaySynonym(objectID, synonymData["input"].(string), castStringSet(synonymData["synonyms"])...)
-		case search.AltCorrection1Type:
-			synonym = search.NewAltCorrection1(objectID, synonymData["word"].(string), castStringSet(synonymData["corrections"])...)
-		case search.AltCorrection2Type:
-			synonym = search.NewAltCorrection2(objectID, synonymData["word"].(string), castStringSet(synonymData["corrections"])...)
-		case search.PlaceholderType:
-			synonym = search.NewPlaceholder(objectID, synonymData["placeholder"].(string), castStringSet(synonymData["replacements"])...)
-		}
-		synonyms = append(synonyms, synonym)
+		synonyms = append(synonyms, mapToSynonym(v.(map[string]interface{})))
 	}
 
 	return synonyms
 }
+
+// mapToSynonym converts the data of a synonym to the typed synonym. It's shared by `algolia_synonyms` and `algolia_synonym`.
+func mapToSynonym(synonymData map[string]interface{}) search.Synonym {
+	objectID := synonymData["object_id"].(string)
+
+	switch search.SynonymType(synonymData["type"].(string)) {
+	case search.RegularSynonymType:
+		return search.NewRegularSynonym(objectID, castStringSet(synonymData["synonyms"])...)
+	case search.OneWaySynonymType:
+		return search.NewOneWaySynonym(objectID, synonymData["input"].(string), castStringSet(synonymData["synonyms"])...)
+	case search.AltCorrection1Type:
+		return search.NewAltCorrection1(objectID, synonymData["word"].(string), castStringSet(synonymData["corrections"])...)
+	case search.AltCorrection2Type:
+		return search.NewAltCorrection2(objectID, synonymData["word"].(string), castStringSet(synonymData["corrections"])...)
+	case search.PlaceholderType:
+		return search.NewPlaceholder(objectID, synonymData["placeholder"].(string), castStringSet(synonymData["replacements"])...)
+	default:
+		return newGenericSynonym(objectID, synonymData)
+	}
+}
+
+// synonymTypes is the list of the synonym types known to the provider.
+var synonymTypes = []string{
+	string(search.RegularSynonymType),
+	string(search.OneWaySynonymType),
+	string(search.AltCorrection1Type),
+	string(search.AltCorrection2Type),
+	string(search.PlaceholderType),
+}
+
+// validateSynonymType warns about the unknown synonym types instead of rejecting them,
+// so that the types newly added to Algolia can be used before the provider catches up.
+func validateSynonymType(v interface{}, k string) ([]string, []error) {
+	synonymType, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	for _, t := range synonymTypes {
+		if synonymType == t {
+			return nil, nil
+		}
+	}
+	return []string{fmt.Sprintf("%s (%q) is not one of %q, it's sent to Algolia as is", k, synonymType, synonymTypes)}, nil
+}
+
+// flattenSynonym converts a raw synonym returned by Algolia to the resource data.
+// The type exported by the Algolia dashboard is lower-cased, so it's normalized to the known type.
+func flattenSynonym(hit map[string]interface{}) map[string]interface{} {
+	synonymType, _ := hit["type"].(string)
+	for _, t := range synonymTypes {
+		if strings.EqualFold(synonymType, t) {
+			synonymType = t
+			break
+		}
+	}
+
+	synonymData := map[string]interface{}{
+		"type": synonymType,
+	}
+	for key, field := range map[string]string{
+		"object_id":   "objectID",
+		"input":       "input",
+		"word":        "word",
+		"placeholder": "placeholder",
+	} {
+		if v, ok := hit[field].(string); ok {
+			synonymData[key] = v
+		}
+	}
+	for _, key := range []string{"synonyms", "corrections", "replacements"} {
+		if v, ok := hit[key].([]interface{}); ok {
+			synonymData[key] = v
+		}
+	}
+	return synonymData
+}
+
+// genericSynonym is a synonym whose type is unknown to the API client.
+type genericSynonym struct {
+	objectID    string
+	synonymType search.SynonymType
+	fields      map[string]interface{}
+}
+
+func newGenericSynonym(objectID string, synonymData map[string]interface{}) genericSynonym {
+	fields := map[string]interface{}{}
+	for key, field := range map[string]string{
+		"input":       "input",
+		"word":        "word",
+		"placeholder": "placeholder",
+	} {
+		if v, ok := synonymData[key].(string); ok && v != "" {
+			fields[field] = v
+		}
+	}
+	for _, key := range []string{"synonyms", "corrections", "replacements"} {
+		if v := castStringSet(synonymData[key]); len(v) > 0 {
+			fields[key] = v
+		}
+	}
+	return genericSynonym{
+		objectID:    objectID,
+		synonymType: search.SynonymType(synonymData["type"].(string)),
+		fields:      fields,
+	}
+}
+
+func (s genericSynonym) ObjectID() string { return s.objectID }
+
+func (s genericSynonym) Type() search.SynonymType { return s.synonymType }
+
+func (s genericSynonym) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"objectID": s.objectID,
+		"type":     s.synonymType,
+	}
+	for k, v := range s.fields {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -43,6 +49,183 @@ func TestAccResourceSynonyms(t *testing.T) {
 	})
 }
 
+func TestResourceSynonyms_validateSynonymObjectIDsNotDuplicated(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"synonyms": []interface{}{
+			map[string]interface{}{"object_id": "test_1", "type": "synonym", "synonyms": []interface{}{"smartphone", "mobile phone"}},
+			map[string]interface{}{"object_id": "test_1", "type": "oneWaySynonym", "input": "smartphone", "synonyms": []interface{}{"iPhone"}},
+		},
+	}
+	_, err := testResourceDiff(resourceSynonyms(), raw)
+	if err == nil || !regexp.MustCompile("synonym object_id 'test_1' is duplicated").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want duplicated object_id error", err)
+	}
+}
+
+func TestResourceSynonyms_validateOneWaySynonymsHaveInput(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"index_name": "test",
+		"synonyms": []interface{}{
+			map[string]interface{}{"object_id": "test_1", "type": "synonym", "synonyms": []interface{}{"smartphone", "mobile phone"}},
+			map[string]interface{}{"object_id": "test_2", "type": "oneWaySynonym", "synonyms": []interface{}{"iPhone"}},
+		},
+	}
+	_, err := testResourceDiff(resourceSynonyms(), raw)
+	if err == nil || !regexp.MustCompile("`input` is required for synonym 'test_2'").MatchString(err.Error()) {
+		t.Errorf("Diff() error = %v, want missing input error", err)
+	}
+}
+
+func TestResourceSynonyms_deleteAlreadyDeletedIndex(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/clear":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSynonyms().Schema, map[string]interface{}{"index_name": "test"})
+	d.SetId("test")
+
+	if diags := resourceSynonymsDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Errorf("resourceSynonymsDelete() error = %v", diags)
+	}
+}
+
+func TestResourceSynonyms_forwardToReplicas(t *testing.T) {
+	t.Parallel()
+
+	var forwardToReplicas []string
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/batch":
+			forwardToReplicas = append(forwardToReplicas, r.URL.Query().Get("forwardToReplicas"))
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/clear":
+			forwardToReplicas = append(forwardToReplicas, r.URL.Query().Get("forwardToReplicas"))
+			_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/test/task/1":
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/search":
+			_, _ = w.Write([]byte(`{"hits":[{"objectID":"test_1","type":"synonym","synonyms":["smartphone","mobile phone"]}],"nbHits":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSynonyms().Schema, map[string]interface{}{
+		"index_name": "test",
+		"synonyms": []interface{}{
+			map[string]interface{}{"object_id": "test_1", "type": "synonym", "synonyms": []interface{}{"smartphone", "mobile phone"}},
+		},
+		"forward_to_replicas": true,
+	})
+
+	if diags := resourceSynonymsCreate(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceSynonymsCreate() error = %v", diags)
+	}
+	// forward_to_replicas isn't returned by Algolia, so it must be kept as configured.
+	if got := d.Get("forward_to_replicas").(bool); !got {
+		t.Errorf("forward_to_replicas = %v, want true", got)
+	}
+	if diags := resourceSynonymsDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceSynonymsDelete() error = %v", diags)
+	}
+	if want := []string{"true", "true"}; !reflect.DeepEqual(forwardToReplicas, want) {
+		t.Errorf("forwardToReplicas of requests = %v, want %v", forwardToReplicas, want)
+	}
+}
+
+func TestResourceSynonyms_validateSynonymType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		synonymType string
+		wantWarning bool
+	}{
+		{name: "known type", synonymType: "oneWaySynonym", wantWarning: false},
+		{name: "unknown type", synonymType: "newSynonymType", wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, errs := validateSynonymType(tt.synonymType, "type")
+			if len(errs) > 0 {
+				t.Errorf("validateSynonymType() errors = %v, want none", errs)
+			}
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("validateSynonymType() warnings = %v, wantWarning %v", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestResourceSynonyms_mapToSynonymsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceSynonyms().Schema, map[string]interface{}{
+		"index_name": "test",
+		"synonyms": []interface{}{
+			map[string]interface{}{"object_id": "test_1", "type": "newSynonymType", "input": "smartphone", "synonyms": []interface{}{"iPhone"}},
+		},
+	})
+
+	got, err := json.Marshal(mapToSynonyms(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"objectID":"test_1","type":"newSynonymType","input":"smartphone","synonyms":["iPhone"]}]`
+	if ok, _ := jsonBytesEqual(got, []byte(want)); !ok {
+		t.Errorf("mapToSynonyms() = %s, want %s", got, want)
+	}
+}
+
+func TestResourceSynonyms_readUnknownType(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/1/indexes/test/synonyms/search":
+			_, _ = w.Write([]byte(`{"hits":[
+				{"objectID":"test_1","type":"onewaysynonym","input":"smartphone","synonyms":["iPhone"]},
+				{"objectID":"test_2","type":"newSynonymType","word":"tv","corrections":["television"]}
+			],"nbHits":2}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSynonyms().Schema, map[string]interface{}{"index_name": "test"})
+	d.SetId("test")
+
+	if diags := resourceSynonymsRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceSynonymsRead() error = %v", diags)
+	}
+
+	got := map[string]string{}
+	for _, v := range d.Get("synonyms").(*schema.Set).List() {
+		synonym := v.(map[string]interface{})
+		got[synonym["object_id"].(string)] = synonym["type"].(string)
+	}
+	want := map[string]string{"test_1": "oneWaySynonym", "test_2": "newSynonymType"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("synonym types = %v, want %v", got, want)
+	}
+}
+
 func testAccResourceSynonyms(indexName string) string {
 	return `
 resource "algolia_index" "` + indexName + `" {
@@ -10,6 +10,7 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,7 +26,11 @@ func resourceVirtualIndex() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVirtualIndexStateContext,
 		},
-		Description: "A configuration for a virtual index.",
+		CustomizeDiff: customdiff.All(
+			resourceVirtualIndexCustomizeDiff,
+			validateMinWordSizesForTypos,
+		),
+		Description: "A configuration for a virtual index. When `distinct` is enabled but the primary index lacks `attribute_for_distinct`, the plan warns about it only in the provider logs, which are shown with `TF_LOG=WARN`.",
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(1 * time.Hour),
 		},
@@ -43,6 +48,12 @@ func resourceVirtualIndex() *schema.Resource {
 				ForceNew:    true,
 				Description: "The name of the existing primary index name.",
 			},
+			"primary_index_replicas": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The replicas of the primary index, read from its settings. The virtual index is listed as `virtual(<name>)` when it's registered as a replica of the primary index.",
+			},
 			"attributes_config": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -153,7 +164,7 @@ func resourceVirtualIndex() *schema.Resource {
 							Set:         schema.HashString,
 							Optional:    true,
 							Computed:    true,
-							Description: "List of attributes to highlight.",
+							Description: "List of attributes to highlight. It's inherited from the primary index when it's not set.",
 						},
 						"attributes_to_snippet": {
 							Type:        schema.TypeSet,
@@ -239,8 +250,8 @@ func resourceVirtualIndex() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "true",
-							ValidateFunc: validation.StringInSlice([]string{"true", "false", "min", "strict"}, false),
-							Description:  "Whether typo tolerance is enabled and how it is applied",
+							ValidateFunc: validateTypoTolerance,
+							Description:  "Whether typo tolerance is enabled and how it is applied. Possible values are `true`, `false`, `min` and `strict`. To configure the word sizes to accept typos, use `min_word_size_for_1_typo` and `min_word_size_for_2_typos` instead.",
 						},
 						"allow_typos_on_numeric_tokens": {
 							Type:        schema.TypeBool,
@@ -441,7 +452,7 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 							Type:         schema.TypeString,
 							Optional:     true,
 							Default:      "attribute",
-							ValidateFunc: validation.StringInSlice([]string{"none", "lastWords", "firstWords", "allOptional"}, false),
+							ValidateFunc: validation.StringInSlice([]string{"attribute", "none", "word"}, false),
 							Description:  "Controls how the exact ranking criterion is computed when the query contains only one word.",
 						},
 						"alternatives_as_exact": {
@@ -511,6 +522,8 @@ List of supported languages are listed on http://nhttps//www.algolia.com/doc/api
 if ` + "`distinct`" + ` is set to 1 (de-duplication):
 - When set to ` + "`N (where N > 1)`" + `, you enable grouping, in which most N hits will be returned with the same value for the distinct attribute.
 then the N most relevant episodes for every show are kept, with similar consequences.
+
+The distinct attribute is inherited from ` + "`attribute_for_distinct`" + ` of the primary index, so it must be set on the primary index.
 `,
 						},
 						"replace_synonyms_in_highlight": {
@@ -575,33 +588,38 @@ func resourceVirtualIndexCreate(ctx context.Context, d *schema.ResourceData, m i
 	primaryIndexSettings, err := primaryIndex.GetSettings(ctx)
 	if err != nil {
 		mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, primaryIndexName))
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	replicas := primaryIndexSettings.Replicas.Get()
 	if !algoliautil.IndexExistsInReplicas(replicas, indexName, true) {
 
 		newReplicas := append(primaryIndexSettings.Replicas.Get(), fmt.Sprintf("virtual(%s)", indexName))
-		res, err := primaryIndex.SetSettings(search.Settings{
-			Replicas: opt.Replicas(newReplicas...),
+		err := retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+			res, err := primaryIndex.SetSettings(search.Settings{
+				Replicas: opt.Replicas(newReplicas...),
+			})
+			if err != nil {
+				return err
+			}
+			return res.Wait()
 		})
 		if err != nil {
 			mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, primaryIndexName))
-			return diag.FromErr(err)
-		}
-		if err := res.Wait(); err != nil {
-			mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, primaryIndexName))
-			return diag.FromErr(err)
+			return diag.FromErr(algoliautil.HumanizeError(err))
 		}
 	}
 	mutexKV.Unlock(ctx, algoliaIndexMutexKey(apiClient.appID, primaryIndexName))
 
 	index := apiClient.searchClient.InitIndex(indexName)
-	res, err := index.SetSettings(mapToVirtualIndexSettings(d))
+	err = retryWrite(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		res, err := index.SetSettings(mapToVirtualIndexSettings(d))
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	d.SetId(indexName)
@@ -620,12 +638,15 @@ func resourceVirtualIndexUpdate(ctx context.Context, d *schema.ResourceData, m i
 	apiClient := m.(*apiClient)
 
 	index := apiClient.searchClient.InitIndex(d.Id())
-	res, err := index.SetSettings(mapToVirtualIndexSettings(d))
+	err := retryWrite(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
+		res, err := index.SetSettings(mapToVirtualIndexSettings(d))
+		if err != nil {
+			return err
+		}
+		return res.Wait()
+	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err = res.Wait(); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return resourceVirtualIndexRead(ctx, d, m)
@@ -647,28 +668,33 @@ func resourceVirtualIndexDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	primaryIndex := apiClient.searchClient.InitIndex(primaryIndexName)
 	primaryIndexSettings, err := primaryIndex.GetSettings(ctx)
-	if err != nil {
-		return diag.FromErr(err)
+	if err != nil && !algoliautil.IsNotFoundError(err) {
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
-	if algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, true) {
+	// The primary index may have been deleted already, then there is no replica setting to update.
+	if err == nil && algoliautil.IndexExistsInReplicas(primaryIndexSettings.Replicas.Get(), indexName, true) {
 		newReplicas := algoliautil.RemoveIndexFromReplicas(primaryIndexSettings.Replicas.Get(), indexName, true)
 		updateReplicasRes, err := primaryIndex.SetSettings(search.Settings{
 			Replicas: opt.Replicas(newReplicas...),
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return diag.FromErr(algoliautil.HumanizeError(err))
 		}
 		if err := updateReplicasRes.Wait(); err != nil {
-			return diag.FromErr(err)
+			return diag.FromErr(algoliautil.HumanizeError(err))
 		}
 	}
 	index := apiClient.searchClient.InitIndex(indexName)
 	deleteIndexRes, err := index.Delete(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		// The index may have been deleted out of band.
+		if algoliautil.IsNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 	if err := deleteIndexRes.Wait(ctx); err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(algoliautil.HumanizeError(err))
 	}
 
 	return nil
@@ -693,7 +719,15 @@ func refreshVirtualIndexState(ctx context.Context, d *schema.ResourceData, m int
 			d.SetId("")
 			return nil
 		}
-		return err
+		return algoliautil.HumanizeError(err)
+	}
+
+	primaryIndexReplicas, err := getPrimaryIndexReplicas(ctx, apiClient, settings.Primary.Get())
+	if err != nil {
+		return algoliautil.HumanizeError(err)
+	}
+	if primaryIndexName := settings.Primary.Get(); primaryIndexName != "" && !algoliautil.IndexExistsInReplicas(primaryIndexReplicas, d.Id(), true) {
+		tflog.Warn(ctx, fmt.Sprintf("virtual index (%s) is not registered in the replicas of primary index (%s)", d.Id(), primaryIndexName))
 	}
 
 	var typoTolerance string
@@ -717,17 +751,12 @@ func refreshVirtualIndexState(ctx context.Context, d *schema.ResourceData, m int
 		removeStopWords = remove
 	}
 
-	var decompoundedAttributes []interface{}
-	for language, attributes := range settings.DecompoundedAttributes.Get() {
-		decompoundedAttributes = append(decompoundedAttributes, map[string]interface{}{
-			"language":   language,
-			"attributes": attributes,
-		})
-	}
+	decompoundedAttributes := flattenDecompoundedAttributes(settings.DecompoundedAttributes.Get())
 
 	values := map[string]interface{}{
-		"name":               d.Id(),
-		"primary_index_name": settings.Primary.Get(),
+		"name":                   d.Id(),
+		"primary_index_name":     settings.Primary.Get(),
+		"primary_index_replicas": primaryIndexReplicas,
 		"attributes_config": []interface{}{map[string]interface{}{
 			"searchable_attributes":    settings.SearchableAttributes.Get(),
 			"attributes_for_faceting":  settings.AttributesForFaceting.Get(),
@@ -812,6 +841,49 @@ func refreshVirtualIndexState(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
+// getPrimaryIndexReplicas returns the replicas of the primary index, or nil if the primary index doesn't exist.
+func getPrimaryIndexReplicas(ctx context.Context, apiClient *apiClient, primaryIndexName string) ([]string, error) {
+	if primaryIndexName == "" {
+		return nil, nil
+	}
+	primaryIndexSettings, err := apiClient.searchClient.InitIndex(primaryIndexName).GetSettings(ctx)
+	if err != nil {
+		if algoliautil.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get settings of primary index (%s): %w", primaryIndexName, err)
+	}
+	return primaryIndexSettings.Replicas.Get(), nil
+}
+
+func resourceVirtualIndexCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("advanced_config.0.distinct").(int) < 1 || !d.NewValueKnown("primary_index_name") {
+		return nil
+	}
+	apiClient, ok := m.(*apiClient)
+	if !ok {
+		return nil
+	}
+
+	// `attribute_for_distinct` can't be set in virtual index, it's inherited from the primary index.
+	// So we check the primary index's setting in the best-effort basis since the primary index may not exist yet.
+	primaryIndexName := d.Get("primary_index_name").(string)
+	primaryIndexSettings, err := apiClient.searchClient.InitIndex(primaryIndexName).GetSettings(ctx)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("skipped checking attribute_for_distinct of primary index (%s): %v", primaryIndexName, err))
+		return nil
+	}
+	if !hasAttributeForDistinct(primaryIndexSettings) {
+		tflog.Warn(ctx, fmt.Sprintf("distinct is enabled on virtual index (%s), but primary index (%s) doesn't have attribute_for_distinct. distinct has no effect until attribute_for_distinct is set on the primary index.", d.Get("name").(string), primaryIndexName))
+	}
+
+	return nil
+}
+
+func hasAttributeForDistinct(settings search.Settings) bool {
+	return settings.AttributeForDistinct.Get() != ""
+}
+
 func mapToVirtualIndexSettings(d *schema.ResourceData) search.Settings {
 	settings := search.Settings{}
 	if v, ok := d.GetOk("attributes_config"); ok {
@@ -824,7 +896,7 @@ func mapToVirtualIndexSettings(d *schema.ResourceData) search.Settings {
 		unmarshalFacetingConfig(v, &settings)
 	}
 	if v, ok := d.GetOk("highlight_and_snippet_config"); ok {
-		unmarshalHighlightAndSnippetConfig(v, &settings)
+		unmarshalHighlightAndSnippetConfig(v, &settings, true)
 	}
 	if v, ok := d.GetOk("pagination_config"); ok {
 		unmarshalPaginationConfig(v, &settings)
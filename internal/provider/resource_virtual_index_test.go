@@ -1,10 +1,22 @@
 package provider
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceVirtualIndex(t *testing.T) {
@@ -26,6 +38,7 @@ func TestAccResourceVirtualIndex(t *testing.T) {
 					// virtual index
 					resource.TestCheckResourceAttr(virtualIndexResourceName, "name", virtualIndexName),
 					resource.TestCheckResourceAttr(virtualIndexResourceName, "deletion_protection", "false"),
+					testCheckResourceListAttr(virtualIndexResourceName, "primary_index_replicas", []string{fmt.Sprintf("virtual(%s)", virtualIndexName)}),
 				),
 			},
 			{
@@ -133,3 +146,289 @@ resource "algolia_virtual_index" "` + virtualIndexName + `" {
 }
 `
 }
+
+func TestResourceVirtualIndex_deleteAlreadyDeletedIndex(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/primary/settings",
+			r.Method == http.MethodDelete && r.URL.Path == "/1/indexes/virtual":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Index does not exist","status":404}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceVirtualIndex().Schema, map[string]interface{}{
+		"name":                "virtual",
+		"primary_index_name":  "primary",
+		"deletion_protection": false,
+	})
+	d.SetId("virtual")
+
+	if diags := resourceVirtualIndexDelete(context.Background(), d, apiClient); diags.HasError() {
+		t.Errorf("resourceVirtualIndexDelete() error = %v", diags)
+	}
+}
+
+func TestResourceVirtualIndex_relevancyStrictnessRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, relevancyStrictness := range []int{0, 50, 100} {
+		relevancyStrictness := relevancyStrictness
+		t.Run(strconv.Itoa(relevancyStrictness), func(t *testing.T) {
+			t.Parallel()
+
+			var savedSettings []byte
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/virtual/settings":
+					savedSettings, _ = io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/task/1":
+					_, _ = w.Write([]byte(`{"status":"published"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/settings":
+					_, _ = w.Write(savedSettings)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceVirtualIndex().Schema, map[string]interface{}{
+				"name":               "virtual",
+				"primary_index_name": "primary",
+				"ranking_config": []interface{}{map[string]interface{}{
+					"custom_ranking":       []interface{}{"desc(price)"},
+					"relevancy_strictness": relevancyStrictness,
+				}},
+			})
+			d.SetId("virtual")
+
+			if diags := resourceVirtualIndexUpdate(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceVirtualIndexUpdate() error = %v", diags)
+			}
+			if got := d.Get("ranking_config.0.relevancy_strictness").(int); got != relevancyStrictness {
+				t.Errorf("relevancy_strictness = %d, want %d, saved settings: %s", got, relevancyStrictness, savedSettings)
+			}
+		})
+	}
+}
+
+func TestResourceVirtualIndex_readInheritedRelevancyStrictness(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/settings":
+			// relevancyStrictness isn't returned when it's not overridden by the virtual index.
+			_, _ = w.Write([]byte(`{"primary":"primary","customRanking":["desc(price)"]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/primary/settings":
+			_, _ = w.Write([]byte(`{"replicas":["virtual(virtual)"]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceVirtualIndex().Schema, map[string]interface{}{
+		"name":               "virtual",
+		"primary_index_name": "primary",
+	})
+	d.SetId("virtual")
+
+	if diags := resourceVirtualIndexRead(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("resourceVirtualIndexRead() error = %v", diags)
+	}
+	// it must be the same as the default not to cause a diff.
+	if got, want := d.Get("ranking_config.0.relevancy_strictness").(int), resourceVirtualIndex().Schema["ranking_config"].Elem.(*schema.Resource).Schema["relevancy_strictness"].Default; got != want {
+		t.Errorf("relevancy_strictness = %v, want %v", got, want)
+	}
+}
+
+func TestResourceVirtualIndex_readPrimaryIndexReplicas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		primarySettings      string
+		primaryStatus        int
+		wantReplicas         []interface{}
+		wantNotRegisteredLog bool
+	}{
+		{
+			name:            "registered",
+			primarySettings: `{"replicas":["standard","virtual(virtual)"]}`,
+			primaryStatus:   http.StatusOK,
+			wantReplicas:    []interface{}{"standard", "virtual(virtual)"},
+		},
+		{
+			name:                 "not registered",
+			primarySettings:      `{"replicas":["standard"]}`,
+			primaryStatus:        http.StatusOK,
+			wantReplicas:         []interface{}{"standard"},
+			wantNotRegisteredLog: true,
+		},
+		{
+			name:                 "primary index is deleted",
+			primarySettings:      `{"message":"Index does not exist","status":404}`,
+			primaryStatus:        http.StatusNotFound,
+			wantReplicas:         []interface{}{},
+			wantNotRegisteredLog: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/settings":
+					_, _ = w.Write([]byte(`{"primary":"primary"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/primary/settings":
+					w.WriteHeader(tt.primaryStatus)
+					_, _ = w.Write([]byte(tt.primarySettings))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceVirtualIndex().Schema, map[string]interface{}{
+				"name":               "virtual",
+				"primary_index_name": "primary",
+			})
+			d.SetId("virtual")
+
+			var logs bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &logs)
+			if diags := resourceVirtualIndexRead(ctx, d, apiClient); diags.HasError() {
+				t.Fatalf("resourceVirtualIndexRead() error = %v", diags)
+			}
+			if got := d.Get("primary_index_replicas").([]interface{}); !reflect.DeepEqual(got, tt.wantReplicas) {
+				t.Errorf("primary_index_replicas = %v, want %v", got, tt.wantReplicas)
+			}
+			if got := strings.Contains(logs.String(), "virtual index (virtual) is not registered in the replicas of primary index (primary)"); got != tt.wantNotRegisteredLog {
+				t.Errorf("warned = %v, want %v, logs: %q", got, tt.wantNotRegisteredLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestResourceVirtualIndex_attributesToHighlightRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		attributesToHighlight []interface{}
+		wantSaved             bool
+		want                  []string
+	}{
+		{
+			name:      "inherited from primary index",
+			wantSaved: false,
+			want:      []string{},
+		},
+		{
+			name:                  "overridden",
+			attributesToHighlight: []interface{}{"title"},
+			wantSaved:             true,
+			want:                  []string{"title"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var savedSettings []byte
+			apiClient := newTestAPIClientWithHandler(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/1/indexes/virtual/settings":
+					savedSettings, _ = io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2030-01-01T00:00:00Z"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/task/1":
+					_, _ = w.Write([]byte(`{"status":"published"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/1/indexes/virtual/settings":
+					_, _ = w.Write(savedSettings)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			highlightAndSnippetConfig := map[string]interface{}{"highlight_pre_tag": "<b>", "highlight_post_tag": "</b>"}
+			if tt.attributesToHighlight != nil {
+				highlightAndSnippetConfig["attributes_to_highlight"] = tt.attributesToHighlight
+			}
+			d := schema.TestResourceDataRaw(t, resourceVirtualIndex().Schema, map[string]interface{}{
+				"name":                         "virtual",
+				"primary_index_name":           "primary",
+				"highlight_and_snippet_config": []interface{}{highlightAndSnippetConfig},
+			})
+			d.SetId("virtual")
+
+			if diags := resourceVirtualIndexUpdate(context.Background(), d, apiClient); diags.HasError() {
+				t.Fatalf("resourceVirtualIndexUpdate() error = %v", diags)
+			}
+			if got := strings.Contains(string(savedSettings), "attributesToHighlight"); got != tt.wantSaved {
+				t.Errorf("attributesToHighlight saved = %v, want %v, saved settings: %s", got, tt.wantSaved, savedSettings)
+			}
+			if got := castStringSet(d.Get("highlight_and_snippet_config.0.attributes_to_highlight")); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attributes_to_highlight = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceVirtualIndex_relevancyStrictnessOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"name":               "virtual",
+		"primary_index_name": "primary",
+		"ranking_config": []interface{}{map[string]interface{}{
+			"relevancy_strictness": 101,
+		}},
+	}
+	if diags := resourceVirtualIndex().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("Validate() error = nil, want out of range error")
+	}
+}
+
+func Test_hasAttributeForDistinct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings search.Settings
+		want     bool
+	}{
+		{
+			name:     "attribute for distinct is set",
+			settings: search.Settings{AttributeForDistinct: opt.AttributeForDistinct("url")},
+			want:     true,
+		},
+		{
+			name:     "attribute for distinct is empty",
+			settings: search.Settings{AttributeForDistinct: opt.AttributeForDistinct("")},
+			want:     false,
+		},
+		{
+			name:     "attribute for distinct is not returned",
+			settings: search.Settings{},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasAttributeForDistinct(tt.settings); got != tt.want {
+				t.Errorf("hasAttributeForDistinct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
+)
+
+// retryWrite calls write until it succeeds as long as it fails with a transient error (e.g. all the hosts respond with 5xx),
+// backing off between the attempts until the timeout expires.
+// write must be idempotent since it may be called again after the request succeeded but waiting for the task failed.
+func retryWrite(ctx context.Context, timeout time.Duration, write func() error) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := write()
+		if algoliautil.IsRetryableError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("write failed with a transient error, retrying: %v", err))
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		return nil
+	})
+}
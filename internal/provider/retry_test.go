@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/errs"
+)
+
+func Test_retryWrite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds after transient errors",
+			errs:         []error{errs.NewNoMoreHostToTryError(), errs.NewNoMoreHostToTryError(), nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "fails without retry on non-transient error",
+			errs:         []error{errs.AlgoliaErr{Message: "invalid settings", Status: 400}, nil},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "succeeds at once",
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int
+			err := retryWrite(context.Background(), 1*time.Minute, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func Test_retryWrite_timeout(t *testing.T) {
+	t.Parallel()
+
+	err := retryWrite(context.Background(), 500*time.Millisecond, func() error {
+		return errs.NewNoMoreHostToTryError()
+	})
+	var noMoreHostErr *errs.NoMoreHostToTryErr
+	if !errors.As(err, &noMoreHostErr) {
+		t.Errorf("retryWrite() error = %v, want the last transient error", err)
+	}
+}
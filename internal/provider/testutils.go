@@ -1,10 +1,21 @@
 package provider
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/region"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-algolia/internal/algoliautil"
 	"github.com/rs/xid"
 )
@@ -30,3 +41,60 @@ func randResourceID(length int) string {
 
 	return uuid + acctest.RandStringFromCharSet(length-len(uuid), acctest.CharSetAlphaNum)
 }
+
+// testResourceDiff plans the given raw config against the empty state to test CustomizeDiff without calling API.
+func testResourceDiff(r *schema.Resource, raw map[string]interface{}) (*terraform.InstanceDiff, error) {
+	return r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+}
+
+// testResourceDiffLogs plans the given raw config against the state and returns the logs written during the plan.
+// The plan must succeed since the warnings of CustomizeDiff never block it.
+func testResourceDiffLogs(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, m interface{}) string {
+	t.Helper()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	if _, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), m); err != nil {
+		t.Fatalf("Diff() error = %v, want nil", err)
+	}
+	return logs.String()
+}
+
+// assertDiffWarns plans the given raw config against the empty state and asserts whether wantMsg is logged.
+func assertDiffWarns(t *testing.T, r *schema.Resource, raw map[string]interface{}, wantMsg string, wantWarn bool) {
+	t.Helper()
+
+	logs := testResourceDiffLogs(t, r, nil, raw, &apiClient{})
+	if got := strings.Contains(logs, wantMsg); got != wantWarn {
+		t.Errorf("warning %q logged = %v, want %v, logs: %q", wantMsg, got, wantWarn, logs)
+	}
+}
+
+// testRequester is a requester to serve API requests by the given handler instead of calling Algolia API.
+type testRequester struct {
+	handler http.HandlerFunc
+}
+
+func (r *testRequester) Request(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	r.handler(rec, req)
+	return rec.Result(), nil
+}
+
+// newTestAPIClientWithHandler returns apiClient which is backed by the given handler.
+func newTestAPIClientWithHandler(handler http.HandlerFunc) *apiClient {
+	requester := &testRequester{handler: handler}
+	return &apiClient{
+		appID:                 "test",
+		apiKey:                "test",
+		userAgent:             "test",
+		requester:             requester,
+		waitForTask:           true,
+		personalizationRegion: region.US,
+		searchClient: algoliaSearchClient{search.NewClientWithConfig(search.Configuration{
+			AppID:     "test",
+			APIKey:    "test",
+			Requester: requester,
+		})},
+	}
+}